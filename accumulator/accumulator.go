@@ -0,0 +1,174 @@
+//go:build !bignum_pure && !bignum_hol256 && !bignum_kilic && !bignum_hbls
+// +build !bignum_pure,!bignum_hol256,!bignum_kilic,!bignum_hbls
+
+package accumulator
+
+import (
+	"fmt"
+
+	"github.com/accumulators-agg/go-poly/ff"
+	"github.com/accumulators-agg/go-poly/fft"
+	"github.com/accumulators-agg/go-poly/kzg"
+	gmcl "github.com/alinush/go-mcl"
+)
+
+// Accumulator of a set, held by the prover. Verifiers only need Digest and the SRS.
+type Accumulator struct {
+	srs *kzg.SRS
+	set []ff.Fr
+	// \prod_i (x - a_i)
+	poly []ff.Fr
+	// [poly(tau)]G1
+	digest gmcl.G1
+}
+
+// Witness that a batch of elements is not in the set: with the Bezout coefficients u and v of
+// the set polynomial P and the batch polynomial Q, u * P + v * Q = 1, so e(A, U) * e(V, [Q(tau)]G2) = e(G1, G2).
+type NonMembershipWitness struct {
+	// [u(tau)]G2
+	U gmcl.G2
+	// [v(tau)]G1
+	V gmcl.G1
+}
+
+// Checks that a batch of elements is not empty and has no duplicates
+func checkElements(elems []ff.Fr) error {
+	if len(elems) == 0 {
+		return ErrEmptyInput
+	}
+	seen := make(map[[ff.FrBytes]byte]struct{}, len(elems))
+	for i := range elems {
+		k := ff.FrToBytes(&elems[i], ff.LittleEndian)
+		if _, ok := seen[k]; ok {
+			return fmt.Errorf("%w: %s appears twice", ErrDuplicateElements, ff.FrStr(&elems[i]))
+		}
+		seen[k] = struct{}{}
+	}
+	return nil
+}
+
+// Accumulates the given set, whose elements must be distinct.
+// srs.G1 needs len(set) + 1 powers.
+func New(srs *kzg.SRS, set []ff.Fr) (*Accumulator, error) {
+	if err := checkElements(set); err != nil {
+		return nil, fmt.Errorf("New: %w", err)
+	}
+	acc := &Accumulator{srs: srs, set: make([]ff.Fr, len(set))}
+	copy(acc.set, set)
+	acc.poly = fft.PolyTree(acc.set)
+	var err error
+	if acc.digest, err = srs.Commit(acc.poly); err != nil {
+		return nil, fmt.Errorf("New: %w", err)
+	}
+	return acc, nil
+}
+
+// Returns the commitment to the set, [\prod_i (tau - a_i)]G1.
+func (acc *Accumulator) Digest() gmcl.G1 {
+	return acc.digest
+}
+
+// Returns the polynomial \prod_i (x - a_i) whose roots are the set.
+func (acc *Accumulator) Poly() []ff.Fr {
+	out := make([]ff.Fr, len(acc.poly))
+	copy(out, acc.poly)
+	return out
+}
+
+// Returns the accumulated set.
+func (acc *Accumulator) Set() []ff.Fr {
+	out := make([]ff.Fr, len(acc.set))
+	copy(out, acc.set)
+	return out
+}
+
+// Returns the witness that every element of subset is in the set: [P(tau) / S(tau)]G1,
+// the commitment to the set polynomial divided by the subset polynomial.
+// Returns ErrNotMember if some element is not in the set.
+func (acc *Accumulator) MembershipWitness(subset []ff.Fr) (gmcl.G1, error) {
+	var w gmcl.G1
+	if err := checkElements(subset); err != nil {
+		return w, fmt.Errorf("MembershipWitness: %w", err)
+	}
+	S := fft.PolyTree(subset)
+	if len(S) > len(acc.poly) {
+		return w, fmt.Errorf("MembershipWitness: %w: the subset is larger than the set", ErrNotMember)
+	}
+	q, r := fft.PolyDiv(acc.poly, S)
+	if fft.IsPolyZero(r) == false {
+		return w, fmt.Errorf("MembershipWitness: %w", ErrNotMember)
+	}
+	w, err := acc.srs.Commit(q)
+	if err != nil {
+		return w, fmt.Errorf("MembershipWitness: %w", err)
+	}
+	return w, nil
+}
+
+// Checks the witness that every element of subset is in the set committed by digest:
+// e(W, [S(tau)]G2) = e(A, [1]G2). srs.G2 needs len(subset) + 1 powers.
+// Returns an error, rather than false, if subset is malformed or the SRS is too small.
+func VerifyMembership(srs *kzg.SRS, digest *gmcl.G1, subset []ff.Fr, witness *gmcl.G1) (bool, error) {
+	if err := checkElements(subset); err != nil {
+		return false, fmt.Errorf("VerifyMembership: %w", err)
+	}
+	sG2, err := srs.CommitG2(fft.PolyTree(subset))
+	if err != nil {
+		return false, fmt.Errorf("VerifyMembership: %w", err)
+	}
+	return ff.PairingsVerify(digest, &srs.G2[0], witness, &sG2), nil
+}
+
+// Returns the witness that no element of batch is in the set, from XGCD(P, Q) of the set
+// polynomial P and the batch polynomial Q. Returns ErrMember if some element is in the set.
+// srs.G2 needs len(batch) powers.
+func (acc *Accumulator) NonMembershipWitness(batch []ff.Fr) (NonMembershipWitness, error) {
+	var w NonMembershipWitness
+	if err := checkElements(batch); err != nil {
+		return w, fmt.Errorf("NonMembershipWitness: %w", err)
+	}
+	Q := fft.PolyTree(batch)
+	g, u, v := fft.XGCD(acc.poly, Q)
+	if len(g) != 1 {
+		// A common root
+		return w, fmt.Errorf("NonMembershipWitness: %w", ErrMember)
+	}
+
+	// Scale so that u * P + v * Q = 1
+	var gInv ff.Fr
+	ff.FrInv(&gInv, &g[0])
+	for i := range u {
+		ff.FrMul(&u[i], &u[i], &gInv)
+	}
+	for i := range v {
+		ff.FrMul(&v[i], &v[i], &gInv)
+	}
+
+	var err error
+	if w.U, err = acc.srs.CommitG2(u); err != nil {
+		return w, fmt.Errorf("NonMembershipWitness: %w", err)
+	}
+	if w.V, err = acc.srs.Commit(v); err != nil {
+		return w, fmt.Errorf("NonMembershipWitness: %w", err)
+	}
+	return w, nil
+}
+
+// Checks the witness that no element of batch is in the set committed by digest:
+// e(A, U) * e(V, [Q(tau)]G2) = e([1]G1, [1]G2). srs.G2 needs len(batch) + 1 powers.
+// Returns an error, rather than false, if batch is malformed or the SRS is too small.
+func VerifyNonMembership(srs *kzg.SRS, digest *gmcl.G1, batch []ff.Fr, witness *NonMembershipWitness) (bool, error) {
+	if err := checkElements(batch); err != nil {
+		return false, fmt.Errorf("VerifyNonMembership: %w", err)
+	}
+	qG2, err := srs.CommitG2(fft.PolyTree(batch))
+	if err != nil {
+		return false, fmt.Errorf("VerifyNonMembership: %w", err)
+	}
+
+	var negG1 gmcl.G1
+	gmcl.G1Neg(&negG1, &srs.G1[0])
+	P := []gmcl.G1{*digest, witness.V, negG1}
+	Q := []gmcl.G2{witness.U, qG2, srs.G2[0]}
+	return ff.PairingProductIsOne(P, Q), nil
+}
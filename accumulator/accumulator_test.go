@@ -0,0 +1,145 @@
+//go:build !bignum_pure && !bignum_hol256 && !bignum_kilic && !bignum_hbls
+// +build !bignum_pure,!bignum_hol256,!bignum_kilic,!bignum_hbls
+
+package accumulator
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/accumulators-agg/go-poly/ff"
+	"github.com/accumulators-agg/go-poly/kzg"
+)
+
+func randomElements(n int) []ff.Fr {
+	a := make([]ff.Fr, n)
+	for i := range a {
+		a[i] = *ff.RandomFr()
+	}
+	return a
+}
+
+func TestMembership(t *testing.T) {
+	var tests = []struct {
+		setLen, subsetLen int
+	}{
+		{1, 1},
+		{8, 3},
+		{8, 8},
+		{200, 150},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%d-%d", tt.setLen, tt.subsetLen), func(t *testing.T) {
+			srs := kzg.NewSRSInsecure(ff.RandomFr(), tt.setLen+1, tt.subsetLen+1)
+			set := randomElements(tt.setLen)
+			acc, err := New(srs, set)
+			if err != nil {
+				t.Fatal(err)
+			}
+			digest := acc.Digest()
+
+			subset := set[tt.setLen-tt.subsetLen:]
+			w, err := acc.MembershipWitness(subset)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if ok, err := VerifyMembership(srs, &digest, subset, &w); err != nil || ok == false {
+				t.Errorf("VerifyMembership: rejected a valid witness, %v", err)
+			}
+
+			// The witness does not prove another subset
+			other := append([]ff.Fr{}, subset...)
+			other[0] = *ff.RandomFr()
+			if ok, err := VerifyMembership(srs, &digest, other, &w); err != nil || ok {
+				t.Errorf("VerifyMembership: accepted a witness for another subset, %v", err)
+			}
+			if _, err := acc.MembershipWitness(other); errors.Is(err, ErrNotMember) == false {
+				t.Errorf("MembershipWitness: expected %v, got %v", ErrNotMember, err)
+			}
+		})
+	}
+}
+
+func TestNonMembership(t *testing.T) {
+	var tests = []struct {
+		setLen, batchLen int
+	}{
+		{1, 1},
+		{8, 3},
+		{3, 8},
+		{200, 150},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%d-%d", tt.setLen, tt.batchLen), func(t *testing.T) {
+			srs := kzg.NewSRSInsecure(ff.RandomFr(), tt.setLen+1, tt.batchLen+1)
+			set := randomElements(tt.setLen)
+			acc, err := New(srs, set)
+			if err != nil {
+				t.Fatal(err)
+			}
+			digest := acc.Digest()
+
+			batch := randomElements(tt.batchLen)
+			w, err := acc.NonMembershipWitness(batch)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if ok, err := VerifyNonMembership(srs, &digest, batch, &w); err != nil || ok == false {
+				t.Errorf("VerifyNonMembership: rejected a valid witness, %v", err)
+			}
+
+			// A member breaks both the witness and the verification
+			withMember := append([]ff.Fr{}, batch...)
+			withMember[tt.batchLen-1] = set[0]
+			if _, err := acc.NonMembershipWitness(withMember); errors.Is(err, ErrMember) == false {
+				t.Errorf("NonMembershipWitness: expected %v, got %v", ErrMember, err)
+			}
+			if ok, err := VerifyNonMembership(srs, &digest, withMember, &w); err != nil || ok {
+				t.Errorf("VerifyNonMembership: accepted a witness for a member, %v", err)
+			}
+		})
+	}
+}
+
+func TestAccumulatorErrors(t *testing.T) {
+	srs := kzg.NewSRSInsecure(ff.RandomFr(), 5, 3)
+	set := randomElements(4)
+	acc, err := New(srs, set)
+	if err != nil {
+		t.Fatal(err)
+	}
+	digest := acc.Digest()
+	dup := []ff.Fr{set[0], set[0]}
+
+	var tests = []struct {
+		name string
+		run  func() error
+		want error
+	}{
+		{"new-empty", func() error { _, err := New(srs, nil); return err }, ErrEmptyInput},
+		{"new-duplicates", func() error { _, err := New(srs, dup); return err }, ErrDuplicateElements},
+		{"new-srs", func() error { _, err := New(srs, randomElements(5)); return err }, kzg.ErrSRSTooSmall},
+		{"membership-duplicates", func() error { _, err := acc.MembershipWitness(dup); return err }, ErrDuplicateElements},
+		{"membership-larger", func() error { _, err := acc.MembershipWitness(append(set, *ff.RandomFr())); return err }, ErrNotMember},
+		{"non-membership-empty", func() error { _, err := acc.NonMembershipWitness(nil); return err }, ErrEmptyInput},
+		{"verify-srs", func() error {
+			_, err := VerifyMembership(srs, &digest, set[:3], &digest)
+			return err
+		}, kzg.ErrSRSTooSmall},
+		{"verify-non-membership-srs", func() error {
+			_, err := VerifyNonMembership(srs, &digest, randomElements(3), &NonMembershipWitness{})
+			return err
+		}, kzg.ErrSRSTooSmall},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.run(); errors.Is(err, tt.want) == false {
+				t.Errorf("expected %v, got %v", tt.want, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,117 @@
+//go:build !bignum_pure && !bignum_hol256 && !bignum_kilic && !bignum_hbls
+// +build !bignum_pure,!bignum_hol256,!bignum_kilic,!bignum_hbls
+
+package accumulator
+
+import (
+	"fmt"
+	"runtime"
+
+	"github.com/accumulators-agg/go-poly/ff"
+	"github.com/accumulators-agg/go-poly/fft"
+	"github.com/accumulators-agg/go-poly/kzg"
+	gmcl "github.com/alinush/go-mcl"
+)
+
+// Returns the union of pairwise disjoint subsets, ErrDuplicateElements if they overlap
+func unionOf(subsets [][]ff.Fr) ([]ff.Fr, error) {
+	if len(subsets) == 0 {
+		return nil, ErrEmptyInput
+	}
+	var union []ff.Fr
+	for j := range subsets {
+		if len(subsets[j]) == 0 {
+			return nil, fmt.Errorf("%w: subset %d", ErrEmptyInput, j)
+		}
+		union = append(union, subsets[j]...)
+	}
+	if err := checkElements(union); err != nil {
+		return nil, err
+	}
+	return union, nil
+}
+
+// Combines the membership witnesses of pairwise disjoint subsets into the witness for their union.
+// The union polynomial is the product of the subset polynomials, multiplied with PolyTreeVec.
+// Needs the set: the witness of the union is not a combination of the subset witnesses with
+// scalar coefficients unless every subset has one element, see AggregateElementWitnesses.
+func (acc *Accumulator) UnionMembershipWitness(subsets [][]ff.Fr) (gmcl.G1, error) {
+	var w gmcl.G1
+	if _, err := unionOf(subsets); err != nil {
+		return w, fmt.Errorf("UnionMembershipWitness: %w", err)
+	}
+	polys := make([][]ff.Fr, len(subsets))
+	for j := range subsets {
+		polys[j] = fft.PolyTree(subsets[j])
+	}
+	U := fft.PolyTreeVec(polys)
+	if len(U) > len(acc.poly) {
+		return w, fmt.Errorf("UnionMembershipWitness: %w: the union is larger than the set", ErrNotMember)
+	}
+	q, r := fft.PolyDiv(acc.poly, U)
+	if fft.IsPolyZero(r) == false {
+		return w, fmt.Errorf("UnionMembershipWitness: %w", ErrNotMember)
+	}
+	w, err := acc.srs.Commit(q)
+	if err != nil {
+		return w, fmt.Errorf("UnionMembershipWitness: %w", err)
+	}
+	return w, nil
+}
+
+// Combines the witnesses [P(tau) / (tau - a_i)]G1 of distinct elements a_i into the witness of
+// all of them, without the set: with U(x) = \prod_i (x - a_i), 1 / U(x) = \sum_i 1 / (U'(a_i) (x - a_i)),
+// so the aggregated witness is \sum_i W_i / U'(a_i).
+// The result only verifies if every input witness does.
+func AggregateElementWitnesses(elems []ff.Fr, witnesses []gmcl.G1) (gmcl.G1, error) {
+	var w gmcl.G1
+	if err := checkElements(elems); err != nil {
+		return w, fmt.Errorf("AggregateElementWitnesses: %w", err)
+	}
+	if len(elems) != len(witnesses) {
+		return w, fmt.Errorf("AggregateElementWitnesses: got %d elements but %d witnesses", len(elems), len(witnesses))
+	}
+
+	U := fft.PolyTree(elems)
+	coeffs := fft.PolyEvalMany(fft.PolyDifferentiate(U), elems)
+	// U'(a_i) != 0 as the elements are distinct
+	ff.BatchInvertParallel(coeffs, coeffs, runtime.GOMAXPROCS(0))
+	ff.MSMG1Parallel(&w, witnesses, coeffs, runtime.GOMAXPROCS(0))
+	return w, nil
+}
+
+// Checks k membership proofs at once: witnesses[j] proves that subsets[j] is in the set committed
+// by digests[j]. With random r_j, checks \prod_j e(r_j W_j, [S_j(tau)]G2) = e(\sum_j r_j A_j, [1]G2)
+// with a single multi-pairing, which fails for an invalid proof except with probability about k / r.
+// Returns an error, rather than false, if the inputs do not fit together or the SRS is too small.
+func BatchVerifyMembership(srs *kzg.SRS, digests []gmcl.G1, subsets [][]ff.Fr, witnesses []gmcl.G1) (bool, error) {
+	k := len(subsets)
+	if k == 0 {
+		return false, fmt.Errorf("BatchVerifyMembership: %w", ErrEmptyInput)
+	}
+	if len(digests) != k || len(witnesses) != k {
+		return false, fmt.Errorf("BatchVerifyMembership: got %d digests and %d witnesses for %d subsets", len(digests), len(witnesses), k)
+	}
+
+	P := make([]gmcl.G1, k+1)
+	Q := make([]gmcl.G2, k+1)
+	var accDigests, tmp gmcl.G1
+	accDigests.Clear()
+	for j := 0; j < k; j++ {
+		if err := checkElements(subsets[j]); err != nil {
+			return false, fmt.Errorf("BatchVerifyMembership: subset %d: %w", j, err)
+		}
+		sG2, err := srs.CommitG2(fft.PolyTree(subsets[j]))
+		if err != nil {
+			return false, fmt.Errorf("BatchVerifyMembership: subset %d: %w", j, err)
+		}
+		r := ff.RandomFr()
+		gmcl.G1Mul(&P[j], &witnesses[j], r)
+		Q[j] = sG2
+		gmcl.G1Mul(&tmp, &digests[j], r)
+		gmcl.G1Add(&accDigests, &accDigests, &tmp)
+	}
+	gmcl.G1Neg(&P[k], &accDigests)
+	Q[k] = srs.G2[0]
+	return ff.PairingProductIsOne(P, Q), nil
+}
@@ -0,0 +1,166 @@
+//go:build !bignum_pure && !bignum_hol256 && !bignum_kilic && !bignum_hbls
+// +build !bignum_pure,!bignum_hol256,!bignum_kilic,!bignum_hbls
+
+package accumulator
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/accumulators-agg/go-poly/ff"
+	"github.com/accumulators-agg/go-poly/kzg"
+	gmcl "github.com/alinush/go-mcl"
+)
+
+func TestUnionMembershipWitness(t *testing.T) {
+	srs := kzg.NewSRSInsecure(ff.RandomFr(), 65, 65)
+	set := randomElements(64)
+	acc, err := New(srs, set)
+	if err != nil {
+		t.Fatal(err)
+	}
+	digest := acc.Digest()
+
+	var tests = [][]int{
+		{1},
+		{3, 5},
+		{1, 2, 3, 4, 5},
+		{10, 20, 34},
+	}
+
+	for counter, sizes := range tests {
+		t.Run(fmt.Sprintf("%d", counter+1), func(t *testing.T) {
+			var subsets [][]ff.Fr
+			var union []ff.Fr
+			start := 0
+			for _, n := range sizes {
+				subsets = append(subsets, set[start:start+n])
+				union = append(union, set[start:start+n]...)
+				start += n
+			}
+
+			w, err := acc.UnionMembershipWitness(subsets)
+			if err != nil {
+				t.Fatal(err)
+			}
+			want, err := acc.MembershipWitness(union)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if w.IsEqual(&want) == false {
+				t.Errorf("UnionMembershipWitness: did not match the witness of the union")
+			}
+			if ok, err := VerifyMembership(srs, &digest, union, &w); err != nil || ok == false {
+				t.Errorf("VerifyMembership: rejected the union witness, %v", err)
+			}
+		})
+	}
+
+	overlapping := [][]ff.Fr{set[0:3], set[2:5]}
+	if _, err := acc.UnionMembershipWitness(overlapping); errors.Is(err, ErrDuplicateElements) == false {
+		t.Errorf("UnionMembershipWitness: expected %v, got %v", ErrDuplicateElements, err)
+	}
+	outside := [][]ff.Fr{set[0:3], randomElements(2)}
+	if _, err := acc.UnionMembershipWitness(outside); errors.Is(err, ErrNotMember) == false {
+		t.Errorf("UnionMembershipWitness: expected %v, got %v", ErrNotMember, err)
+	}
+	if _, err := acc.UnionMembershipWitness([][]ff.Fr{set[0:3], {}}); errors.Is(err, ErrEmptyInput) == false {
+		t.Errorf("UnionMembershipWitness: expected %v, got %v", ErrEmptyInput, err)
+	}
+}
+
+func TestAggregateElementWitnesses(t *testing.T) {
+	srs := kzg.NewSRSInsecure(ff.RandomFr(), 33, 33)
+	set := randomElements(32)
+	acc, err := New(srs, set)
+	if err != nil {
+		t.Fatal(err)
+	}
+	digest := acc.Digest()
+
+	for _, k := range []int{1, 2, 7, 32} {
+		t.Run(fmt.Sprintf("%d", k), func(t *testing.T) {
+			elems := set[:k]
+			witnesses := make([]gmcl.G1, k)
+			for i := range elems {
+				if witnesses[i], err = acc.MembershipWitness(elems[i : i+1]); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			w, err := AggregateElementWitnesses(elems, witnesses)
+			if err != nil {
+				t.Fatal(err)
+			}
+			want, _ := acc.MembershipWitness(elems)
+			if w.IsEqual(&want) == false {
+				t.Errorf("AggregateElementWitnesses: did not match the witness of all elements")
+			}
+			if ok, err := VerifyMembership(srs, &digest, elems, &w); err != nil || ok == false {
+				t.Errorf("VerifyMembership: rejected the aggregated witness, %v", err)
+			}
+
+			if k > 1 {
+				witnesses[0] = witnesses[1]
+				w, _ = AggregateElementWitnesses(elems, witnesses)
+				if ok, _ := VerifyMembership(srs, &digest, elems, &w); ok {
+					t.Errorf("VerifyMembership: accepted an aggregate of a wrong witness")
+				}
+			}
+		})
+	}
+
+	if _, err := AggregateElementWitnesses(set[:2], make([]gmcl.G1, 3)); err == nil {
+		t.Errorf("AggregateElementWitnesses: expected an error on a length mismatch")
+	}
+}
+
+func TestBatchVerifyMembership(t *testing.T) {
+	srs := kzg.NewSRSInsecure(ff.RandomFr(), 17, 9)
+
+	// Proofs against two accumulators
+	var digests, witnesses []gmcl.G1
+	var subsets [][]ff.Fr
+	for _, setLen := range []int{16, 9} {
+		set := randomElements(setLen)
+		acc, err := New(srs, set)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, subset := range [][]ff.Fr{set[:1], set[1:4], set[4:9]} {
+			w, err := acc.MembershipWitness(subset)
+			if err != nil {
+				t.Fatal(err)
+			}
+			digests = append(digests, acc.Digest())
+			subsets = append(subsets, subset)
+			witnesses = append(witnesses, w)
+		}
+	}
+
+	if ok, err := BatchVerifyMembership(srs, digests, subsets, witnesses); err != nil || ok == false {
+		t.Errorf("BatchVerifyMembership: rejected valid proofs, %v", err)
+	}
+
+	// Any wrong proof breaks the batch
+	for j := range subsets {
+		bad := append([]gmcl.G1{}, witnesses...)
+		bad[j] = witnesses[(j+1)%len(witnesses)]
+		if ok, err := BatchVerifyMembership(srs, digests, subsets, bad); err != nil || ok {
+			t.Errorf("BatchVerifyMembership: accepted a wrong witness %d, %v", j, err)
+		}
+	}
+	swapped := append([]gmcl.G1{}, digests...)
+	swapped[0], swapped[len(swapped)-1] = swapped[len(swapped)-1], swapped[0]
+	if ok, err := BatchVerifyMembership(srs, swapped, subsets, witnesses); err != nil || ok {
+		t.Errorf("BatchVerifyMembership: accepted proofs against the wrong digests, %v", err)
+	}
+
+	if _, err := BatchVerifyMembership(srs, digests[:1], subsets, witnesses); err == nil {
+		t.Errorf("BatchVerifyMembership: expected an error on a length mismatch")
+	}
+	if _, err := BatchVerifyMembership(srs, nil, nil, nil); errors.Is(err, ErrEmptyInput) == false {
+		t.Errorf("BatchVerifyMembership: expected %v, got %v", ErrEmptyInput, err)
+	}
+}
@@ -0,0 +1,5 @@
+// Package accumulator implements a bilinear accumulator: a set {a_i} is committed as
+// [\prod_i (tau - a_i)]G1 with the SRS of the kzg package, and membership and non-membership
+// of batches of elements are proven with short witnesses checked by pairings.
+// The package is empty with the pure Go field backend, which has no groups.
+package accumulator
@@ -0,0 +1,15 @@
+package accumulator
+
+import "errors"
+
+// Sentinel errors, returned errors wrap them with more context, match them with errors.Is.
+var (
+	// A set or batch of elements is empty.
+	ErrEmptyInput = errors.New("empty input")
+	// A set or batch of elements contains an element twice.
+	ErrDuplicateElements = errors.New("elements are not distinct")
+	// An element of a membership batch is not in the set.
+	ErrNotMember = errors.New("element is not in the set")
+	// An element of a non-membership batch is in the set.
+	ErrMember = errors.New("element is in the set")
+)
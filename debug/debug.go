@@ -5,10 +5,9 @@ import (
 	"strings"
 
 	"github.com/accumulators-agg/go-poly/ff"
-	gmcl "github.com/alinush/go-mcl"
 )
 
-func DebugFrPtrs(msg string, values []*gmcl.Fr) {
+func DebugFrPtrs(msg string, values []*ff.Fr) {
 	var out strings.Builder
 	out.WriteString("---")
 	out.WriteString(msg)
@@ -19,7 +18,7 @@ func DebugFrPtrs(msg string, values []*gmcl.Fr) {
 	fmt.Println(out.String())
 }
 
-func DebugFrs(msg string, values []gmcl.Fr) {
+func DebugFrs(msg string, values []ff.Fr) {
 	fmt.Println("---------------------------")
 	var out strings.Builder
 	for i := range values {
@@ -0,0 +1,94 @@
+package ff
+
+import (
+	"fmt"
+	"sync"
+)
+
+// Below this many elements per worker, BatchInvertParallel does not spawn goroutines:
+// the single inversion a worker saves costs about as much as this many multiplications.
+const batchInvertParallelThreshold = 1 << 10
+
+// Sets dst[i] = 1 / src[i] with one inversion and 3(n-1) multiplications (Montgomery's trick).
+// Zero entries are skipped and their inverse is zero, like FrInv. dst may alias src.
+// Panics if dst and src have different lengths.
+func BatchInvert(dst []Fr, src []Fr) {
+	if len(dst) != len(src) {
+		panic(fmt.Sprintf("BatchInvert: Got %d outputs for %d inputs", len(dst), len(src)))
+	}
+	batchInvert(dst, src)
+}
+
+// Same as BatchInvert, splitting large slices among up to workers goroutines.
+// Each goroutine inverts its chunk with its own inversion. Results do not depend on workers.
+func BatchInvertParallel(dst []Fr, src []Fr, workers int) {
+	if len(dst) != len(src) {
+		panic(fmt.Sprintf("BatchInvertParallel: Got %d outputs for %d inputs", len(dst), len(src)))
+	}
+	n := len(src)
+	if maxWorkers := n / batchInvertParallelThreshold; workers > maxWorkers {
+		workers = maxWorkers
+	}
+	if workers <= 1 {
+		batchInvert(dst, src)
+		return
+	}
+
+	chunk := (n + workers - 1) / workers
+	var wg sync.WaitGroup
+	for start := 0; start < n; start += chunk {
+		end := start + chunk
+		if end > n {
+			end = n
+		}
+		wg.Add(1)
+		go func(start, end int) {
+			defer wg.Done()
+			batchInvert(dst[start:end], src[start:end])
+		}(start, end)
+	}
+	wg.Wait()
+}
+
+func batchInvert(dst []Fr, src []Fr) {
+	n := len(src)
+	if n == 0 {
+		return
+	}
+
+	// prefix[i] is the product of the non-zero entries of src[:i+1]
+	// (one while there is none yet)
+	prefix := make([]Fr, n, n)
+	var acc Fr
+	acc.SetInt64(1)
+	started := false
+	for i := 0; i < n; i++ {
+		if !src[i].IsZero() {
+			if started {
+				FrMul(&acc, &acc, &src[i])
+			} else {
+				acc = src[i]
+				started = true
+			}
+		}
+		prefix[i] = acc
+	}
+
+	// acc = 1 / prefix[i], peeled off one entry at a time
+	FrInv(&acc, &acc)
+	for i := n - 1; i >= 0; i-- {
+		if src[i].IsZero() {
+			dst[i].Clear()
+			continue
+		}
+		if i == 0 {
+			dst[0] = acc
+			break
+		}
+		var inv Fr
+		FrMul(&inv, &acc, &prefix[i-1])
+		// Read src[i] before dst[i] is written, in case they alias
+		FrMul(&acc, &acc, &src[i])
+		dst[i] = inv
+	}
+}
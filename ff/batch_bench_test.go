@@ -0,0 +1,21 @@
+package ff
+
+import (
+	"fmt"
+	"testing"
+)
+
+func BenchmarkBatchInvert(b *testing.B) {
+	for _, n := range []int{1 << 4, 1 << 10, 1 << 14} {
+		src := make([]Fr, n, n)
+		for i := range src {
+			src[i] = *RandomFr()
+		}
+		dst := make([]Fr, n, n)
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				BatchInvert(dst, src)
+			}
+		})
+	}
+}
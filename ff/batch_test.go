@@ -0,0 +1,73 @@
+package ff
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestBatchInvert(t *testing.T) {
+	var tests = []struct {
+		n     int
+		zeros []int
+	}{
+		{0, nil},
+		{1, nil},
+		{1, []int{0}},
+		{2, []int{0}},
+		{7, nil},
+		{7, []int{0, 3, 6}},
+		{5, []int{0, 1, 2, 3, 4}},
+		{3000, []int{1, 1024, 2999}},
+	}
+
+	for counter, tt := range tests {
+		testname := fmt.Sprintf("%d", counter+1)
+		t.Run(testname, func(t *testing.T) {
+			src := make([]Fr, tt.n, tt.n)
+			for i := range src {
+				src[i] = *RandomFr()
+			}
+			for _, i := range tt.zeros {
+				src[i].Clear()
+			}
+			want := make([]Fr, tt.n, tt.n)
+			for i := range src {
+				FrInv(&want[i], &src[i])
+			}
+
+			check := func(name string, got []Fr) {
+				for i := range want {
+					if got[i].IsEqual(&want[i]) == false {
+						t.Errorf("%s: entry %d did not match with FrInv", name, i)
+					}
+				}
+			}
+
+			dst := make([]Fr, tt.n, tt.n)
+			BatchInvert(dst, src)
+			check("BatchInvert", dst)
+			for _, workers := range []int{1, 2, 3, 8} {
+				dst := make([]Fr, tt.n, tt.n)
+				BatchInvertParallel(dst, src, workers)
+				check(fmt.Sprintf("BatchInvertParallel-%d", workers), dst)
+			}
+
+			inplace := make([]Fr, tt.n, tt.n)
+			copy(inplace, src)
+			BatchInvert(inplace, inplace)
+			check("BatchInvert in place", inplace)
+			copy(inplace, src)
+			BatchInvertParallel(inplace, inplace, 3)
+			check("BatchInvertParallel in place", inplace)
+		})
+	}
+}
+
+func TestBatchInvertLengthMismatch(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("BatchInvert: expected a panic on a length mismatch")
+		}
+	}()
+	BatchInvert(make([]Fr, 2), make([]Fr, 3))
+}
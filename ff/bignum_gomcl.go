@@ -1,32 +1,27 @@
+//go:build !bignum_pure && !bignum_hol256 && !bignum_kilic && !bignum_hbls
 // +build !bignum_pure,!bignum_hol256,!bignum_kilic,!bignum_hbls
 
 package ff
 
 import (
+	"encoding/hex"
+	"math/big"
+
 	gmcl "github.com/alinush/go-mcl"
 )
 
-func init() {
-	gmcl.InitFromString("bls12-381")
-	initGlobals()
-	ZERO_G1.Clear()
-	initG1G2()
-}
-
-func SetFr(dst *gmcl.Fr, v string) {
-	if err := dst.SetString(v, 10); err != nil {
-		panic(err)
-	}
-}
+// Scalar field element, backed by mcl.
+type Fr = gmcl.Fr
 
-func RandomFr() *gmcl.Fr {
-	var out gmcl.Fr
-	out.Random()
-	return &out
+func initBackend(c Curve, modulus *big.Int) error {
+	gmcl.InitFromString(curves[c].name)
+	ZERO_G1.Clear()
+	initG1G2(c)
+	return nil
 }
 
 // FrTo32 serializes a fr number to 32 bytes. Encoded little-endian.
-func FrTo32(src *gmcl.Fr) (v [32]byte) {
+func FrTo32(src *Fr) (v [32]byte) {
 	b := src.Serialize()
 	last := len(b) - 1
 	// reverse endianness, Herumi outputs big-endian bytes
@@ -37,44 +32,47 @@ func FrTo32(src *gmcl.Fr) (v [32]byte) {
 	return
 }
 
-func CopyFr(dst *gmcl.Fr, v *gmcl.Fr) {
-	*dst = *v
+// Big-endian bytes of src.
+// Goes through hex strings, which unlike Serialize do not depend on the io mode of mcl.
+func frToBytesBE(src *Fr) (v [FrBytes]byte) {
+	b, ok := new(big.Int).SetString(src.GetString(16), 16)
+	if !ok {
+		panic("frToBytesBE: mcl returned an invalid hex string")
+	}
+	b.FillBytes(v[:])
+	return
 }
 
-func AsFr(dst *gmcl.Fr, i uint64) {
-	dst.SetInt64(int64(i))
+// Sets dst from big-endian bytes, which must be below the modulus.
+func frSetBytesBE(dst *Fr, v *[FrBytes]byte) {
+	if err := dst.SetString(hex.EncodeToString(v[:]), 16); err != nil {
+		panic(err)
+	}
 }
 
-func FrStr(b *gmcl.Fr) string {
-	if b == nil {
-		return "<nil>"
-	}
-	return b.GetString(10)
+// Field arithmetic, every backend provides the same functions.
+// dst may alias the operands.
+
+func FrAdd(dst *Fr, a *Fr, b *Fr) {
+	gmcl.FrAdd(dst, a, b)
 }
 
-func IntAsFr(dst *gmcl.Fr, i int64) {
-	dst.SetInt64(i)
+func FrSub(dst *Fr, a *Fr, b *Fr) {
+	gmcl.FrSub(dst, a, b)
 }
 
-func FromInt64Vec(in []int64) []gmcl.Fr {
-	n := len(in)
-	dst := make([]gmcl.Fr, n, n)
-	for i := 0; i < n; i++ {
-		(&dst[i]).SetInt64(in[i])
-	}
-	return dst
+func FrMul(dst *Fr, a *Fr, b *Fr) {
+	gmcl.FrMul(dst, a, b)
 }
 
-func MulVecFr(a, b []gmcl.Fr) []gmcl.Fr {
+func FrDiv(dst *Fr, a *Fr, b *Fr) {
+	gmcl.FrDiv(dst, a, b)
+}
 
-	n := len(a)
-	if n == len(b) && n > 0 {
-		result := make([]gmcl.Fr, n, n)
-		for i := 0; i < n; i++ {
-			gmcl.FrMul(&result[i], &a[i], &b[i])
-		}
-		return result
-	}
-	result := make([]gmcl.Fr, 0)
-	return result
+func FrNeg(dst *Fr, a *Fr) {
+	gmcl.FrNeg(dst, a)
+}
+
+func FrInv(dst *Fr, a *Fr) {
+	gmcl.FrInv(dst, a)
 }
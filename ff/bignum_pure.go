@@ -0,0 +1,280 @@
+//go:build bignum_pure || bignum_hol256 || bignum_kilic || bignum_hbls
+// +build bignum_pure bignum_hol256 bignum_kilic bignum_hbls
+
+package ff
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"math/bits"
+)
+
+// Scalar field element of the selected curve, in pure Go.
+// Kept in Montgomery form: four little-endian 64-bit limbs of a * 2^256 mod r.
+// The representation is canonical, so elements can be compared with ==.
+type Fr struct {
+	v [4]uint64
+}
+
+// Montgomery parameters of the modulus r of the selected curve, set by initBackend.
+var (
+	// r
+	frModulus [4]uint64
+	// r - 2, the exponent of the inverse
+	frModulusMinus2 [4]uint64
+	// 2^256 mod r, i.e. one in Montgomery form
+	frR [4]uint64
+	// 2^512 mod r, converts into Montgomery form
+	frR2 [4]uint64
+	// -r^{-1} mod 2^64
+	frInv uint64
+
+	frModulusBig *big.Int
+)
+
+func initBackend(c Curve, modulus *big.Int) error {
+	// The additions and the final reduction of frMontMul rely on 2r < 2^256.
+	if modulus.BitLen() > 255 || modulus.Bit(0) == 0 {
+		return fmt.Errorf("modulus of curve %s is not an odd number below 2^255", c)
+	}
+	frModulusBig = new(big.Int).Set(modulus)
+	frModulus = bigToLimbs(modulus)
+	frModulusMinus2 = bigToLimbs(new(big.Int).Sub(modulus, big.NewInt(2)))
+	frR = bigToLimbs(new(big.Int).Mod(new(big.Int).Lsh(big.NewInt(1), 256), modulus))
+	frR2 = bigToLimbs(new(big.Int).Mod(new(big.Int).Lsh(big.NewInt(1), 512), modulus))
+
+	two64 := new(big.Int).Lsh(big.NewInt(1), 64)
+	inv := new(big.Int).ModInverse(new(big.Int).SetUint64(frModulus[0]), two64)
+	frInv = new(big.Int).Sub(two64, inv).Uint64()
+	return nil
+}
+
+// Little-endian limbs of b, which must be below 2^256
+func bigToLimbs(b *big.Int) (v [4]uint64) {
+	var buf [32]byte
+	b.FillBytes(buf[:])
+	for i := 0; i < 4; i++ {
+		v[i] = binary.BigEndian.Uint64(buf[24-8*i:])
+	}
+	return
+}
+
+// Big-endian bytes of little-endian limbs
+func limbsToBytes(v *[4]uint64) []byte {
+	b := make([]byte, 32)
+	for i := 0; i < 4; i++ {
+		binary.BigEndian.PutUint64(b[24-8*i:], v[i])
+	}
+	return b
+}
+
+// Returns a - r, or a if a < r. Requires a < 2r.
+func frReduce(a *[4]uint64) [4]uint64 {
+	var s [4]uint64
+	var borrow uint64
+	s[0], borrow = bits.Sub64(a[0], frModulus[0], 0)
+	s[1], borrow = bits.Sub64(a[1], frModulus[1], borrow)
+	s[2], borrow = bits.Sub64(a[2], frModulus[2], borrow)
+	s[3], borrow = bits.Sub64(a[3], frModulus[3], borrow)
+	if borrow != 0 {
+		return *a
+	}
+	return s
+}
+
+// Montgomery multiplication (CIOS): returns a * b / 2^256 mod r
+func frMontMul(a *[4]uint64, b *[4]uint64) [4]uint64 {
+	var t [6]uint64
+	var c, cc, hi, lo uint64
+	for i := 0; i < 4; i++ {
+		// t += a * b[i]
+		c = 0
+		for j := 0; j < 4; j++ {
+			hi, lo = bits.Mul64(a[j], b[i])
+			lo, cc = bits.Add64(lo, t[j], 0)
+			hi += cc
+			lo, cc = bits.Add64(lo, c, 0)
+			hi += cc
+			t[j] = lo
+			c = hi
+		}
+		t[4], cc = bits.Add64(t[4], c, 0)
+		t[5] = cc
+
+		// t = (t + m * r) / 2^64, with m chosen so that the low limb cancels
+		m := t[0] * frInv
+		hi, lo = bits.Mul64(m, frModulus[0])
+		_, cc = bits.Add64(lo, t[0], 0)
+		c = hi + cc
+		for j := 1; j < 4; j++ {
+			hi, lo = bits.Mul64(m, frModulus[j])
+			lo, cc = bits.Add64(lo, t[j], 0)
+			hi += cc
+			lo, cc = bits.Add64(lo, c, 0)
+			hi += cc
+			t[j-1] = lo
+			c = hi
+		}
+		t[3], cc = bits.Add64(t[4], c, 0)
+		t[4] = t[5] + cc
+	}
+	// 2r < 2^256, so t < 2r fits in four limbs
+	res := [4]uint64{t[0], t[1], t[2], t[3]}
+	return frReduce(&res)
+}
+
+func (x *Fr) setBig(b *big.Int) {
+	x.v = bigToLimbs(b)
+	x.v = frMontMul(&x.v, &frR2)
+}
+
+// Returns the canonical limbs, out of Montgomery form
+func (x *Fr) canonical() [4]uint64 {
+	one := [4]uint64{1, 0, 0, 0}
+	return frMontMul(&x.v, &one)
+}
+
+func (x *Fr) big() *big.Int {
+	c := x.canonical()
+	return new(big.Int).SetBytes(limbsToBytes(&c))
+}
+
+func (x *Fr) IsZero() bool {
+	return x.v == [4]uint64{}
+}
+
+func (x *Fr) IsOne() bool {
+	return x.v == frR
+}
+
+func (x *Fr) IsEqual(rhs *Fr) bool {
+	return x.v == rhs.v
+}
+
+func (x *Fr) Clear() {
+	x.v = [4]uint64{}
+}
+
+func (x *Fr) SetInt64(v int64) {
+	u := uint64(v)
+	if v < 0 {
+		u = uint64(-v)
+	}
+	x.v = [4]uint64{u, 0, 0, 0}
+	x.v = frMontMul(&x.v, &frR2)
+	if v < 0 {
+		FrNeg(x, x)
+	}
+}
+
+// Parses a number in the given base, which must be in [0, r).
+func (x *Fr) SetString(s string, base int) error {
+	b, ok := new(big.Int).SetString(s, base)
+	if !ok {
+		return fmt.Errorf("invalid number %q", s)
+	}
+	if b.Sign() < 0 || b.Cmp(frModulusBig) >= 0 {
+		return fmt.Errorf("%s is not in the range of the field", s)
+	}
+	x.setBig(b)
+	return nil
+}
+
+func (x *Fr) GetString(base int) string {
+	return x.big().Text(base)
+}
+
+// Sets x to a uniformly random element.
+func (x *Fr) Random() {
+	b, err := rand.Int(rand.Reader, frModulusBig)
+	if err != nil {
+		panic(err)
+	}
+	x.setBig(b)
+}
+
+// FrTo32 serializes a fr number to 32 bytes. Encoded little-endian.
+func FrTo32(src *Fr) (v [32]byte) {
+	c := src.canonical()
+	for i := 0; i < 4; i++ {
+		binary.LittleEndian.PutUint64(v[8*i:], c[i])
+	}
+	return
+}
+
+// Big-endian bytes of src.
+func frToBytesBE(src *Fr) (v [FrBytes]byte) {
+	c := src.canonical()
+	copy(v[:], limbsToBytes(&c))
+	return
+}
+
+// Sets dst from big-endian bytes, which must be below the modulus.
+func frSetBytesBE(dst *Fr, v *[FrBytes]byte) {
+	for i := 0; i < 4; i++ {
+		dst.v[i] = binary.BigEndian.Uint64(v[24-8*i:])
+	}
+	dst.v = frMontMul(&dst.v, &frR2)
+}
+
+// Field arithmetic, every backend provides the same functions.
+// dst may alias the operands.
+
+func FrAdd(dst *Fr, a *Fr, b *Fr) {
+	var t [4]uint64
+	var c uint64
+	t[0], c = bits.Add64(a.v[0], b.v[0], 0)
+	t[1], c = bits.Add64(a.v[1], b.v[1], c)
+	t[2], c = bits.Add64(a.v[2], b.v[2], c)
+	t[3], _ = bits.Add64(a.v[3], b.v[3], c)
+	dst.v = frReduce(&t)
+}
+
+func FrSub(dst *Fr, a *Fr, b *Fr) {
+	var t [4]uint64
+	var borrow, c uint64
+	t[0], borrow = bits.Sub64(a.v[0], b.v[0], 0)
+	t[1], borrow = bits.Sub64(a.v[1], b.v[1], borrow)
+	t[2], borrow = bits.Sub64(a.v[2], b.v[2], borrow)
+	t[3], borrow = bits.Sub64(a.v[3], b.v[3], borrow)
+	if borrow != 0 {
+		t[0], c = bits.Add64(t[0], frModulus[0], 0)
+		t[1], c = bits.Add64(t[1], frModulus[1], c)
+		t[2], c = bits.Add64(t[2], frModulus[2], c)
+		t[3], _ = bits.Add64(t[3], frModulus[3], c)
+	}
+	dst.v = t
+}
+
+func FrMul(dst *Fr, a *Fr, b *Fr) {
+	dst.v = frMontMul(&a.v, &b.v)
+}
+
+// Computes a / b, zero if b is zero.
+func FrDiv(dst *Fr, a *Fr, b *Fr) {
+	var inv Fr
+	FrInv(&inv, b)
+	FrMul(dst, a, &inv)
+}
+
+func FrNeg(dst *Fr, a *Fr) {
+	var zero Fr
+	FrSub(dst, &zero, a)
+}
+
+// Computes a^{-1} = a^{r-2}, zero if a is zero.
+func FrInv(dst *Fr, a *Fr) {
+	base := a.v
+	res := frR
+	for i := 3; i >= 0; i-- {
+		for j := 63; j >= 0; j-- {
+			res = frMontMul(&res, &res)
+			if (frModulusMinus2[i]>>uint(j))&1 == 1 {
+				res = frMontMul(&res, &base)
+			}
+		}
+	}
+	dst.v = res
+}
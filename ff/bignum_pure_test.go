@@ -0,0 +1,90 @@
+//go:build bignum_pure || bignum_hol256 || bignum_kilic || bignum_hbls
+// +build bignum_pure bignum_hol256 bignum_kilic bignum_hbls
+
+package ff
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+)
+
+// Checks the limb arithmetic against math/big.
+func TestPureArith(t *testing.T) {
+	for _, c := range []Curve{BLS12_381, BN254} {
+		withCurve(t, c, testPureArith)
+	}
+}
+
+func testPureArith(t *testing.T) {
+	r := frModulusBig
+	rMinus1 := new(big.Int).Sub(r, big.NewInt(1))
+
+	values := []*big.Int{big.NewInt(0), big.NewInt(1), big.NewInt(2), rMinus1, new(big.Int).Rsh(r, 1)}
+	for i := 0; i < 20; i++ {
+		values = append(values, RandomFr().big())
+	}
+
+	for i, a := range values {
+		for j, b := range values {
+			testname := fmt.Sprintf("%d-%d", i, j)
+			t.Run(testname, func(t *testing.T) {
+				var x, y, z Fr
+				x.setBig(a)
+				y.setBig(b)
+				check := func(name string, got *Fr, want *big.Int) {
+					want.Mod(want, r)
+					if got.big().Cmp(want) != 0 {
+						t.Errorf("%s: got %s, expected %s", name, got.GetString(10), want.String())
+					}
+				}
+
+				FrAdd(&z, &x, &y)
+				check("FrAdd", &z, new(big.Int).Add(a, b))
+				FrSub(&z, &x, &y)
+				check("FrSub", &z, new(big.Int).Sub(a, b))
+				FrMul(&z, &x, &y)
+				check("FrMul", &z, new(big.Int).Mul(a, b))
+				FrNeg(&z, &x)
+				check("FrNeg", &z, new(big.Int).Neg(a))
+				if b.Sign() != 0 {
+					FrDiv(&z, &x, &y)
+					check("FrDiv", &z, new(big.Int).Mul(a, new(big.Int).ModInverse(b, r)))
+				}
+			})
+		}
+	}
+}
+
+func TestPureConversions(t *testing.T) {
+	var x Fr
+
+	for _, v := range []int64{0, 1, -1, 5, -5, 1 << 62, -(1 << 63)} {
+		x.SetInt64(v)
+		want := new(big.Int).Mod(big.NewInt(v), frModulusBig)
+		if x.big().Cmp(want) != 0 {
+			t.Errorf("SetInt64(%d): got %s, expected %s", v, x.GetString(10), want.String())
+		}
+	}
+
+	x.SetInt64(1)
+	if x.IsOne() == false || x.IsZero() {
+		t.Errorf("SetInt64(1): expected one")
+	}
+
+	s := "43599901455287962219281063402626541872197057165786841304067502694013639882090"
+	if err := x.SetString(s, 10); err != nil || x.GetString(10) != s {
+		t.Errorf("SetString: roundtrip failed for %s", s)
+	}
+	for _, bad := range []string{"", "abc", "-1", frModulusBig.String()} {
+		if err := x.SetString(bad, 10); err == nil {
+			t.Errorf("SetString: expected an error for %q", bad)
+		}
+	}
+
+	x.SetInt64(0x0102)
+	v := FrTo32(&x)
+	if v[0] != 0x02 || v[1] != 0x01 || v[31] != 0 {
+		t.Errorf("FrTo32: expected little-endian bytes, got %x", v)
+	}
+}
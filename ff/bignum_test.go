@@ -2,9 +2,6 @@ package ff
 
 import (
 	"testing"
-
-	"github.com/alinush/go-mcl"
-	gmcl "github.com/alinush/go-mcl"
 )
 
 // These are sanity tests, to see if whatever bignum library that is being
@@ -13,14 +10,14 @@ import (
 func TestInplaceAdd(t *testing.T) {
 	aVal := RandomFr()
 	bVal := RandomFr()
-	aPlusB := new(gmcl.Fr)
-	gmcl.FrAdd(aPlusB, aVal, bVal)
-	twoA := new(gmcl.Fr)
-	gmcl.FrMul(twoA, aVal, &TWO)
+	aPlusB := new(Fr)
+	FrAdd(aPlusB, aVal, bVal)
+	twoA := new(Fr)
+	FrMul(twoA, aVal, &TWO)
 
-	check := func(name string, fn func(a, b *gmcl.Fr) bool) {
+	check := func(name string, fn func(a, b *Fr) bool) {
 		t.Run(name, func(t *testing.T) {
-			var a, b gmcl.Fr
+			var a, b Fr
 			CopyFr(&a, aVal)
 			CopyFr(&b, bVal)
 			if !fn(&a, &b) {
@@ -28,16 +25,16 @@ func TestInplaceAdd(t *testing.T) {
 			}
 		})
 	}
-	check("dst equals lhs", func(a *gmcl.Fr, b *gmcl.Fr) bool {
-		gmcl.FrAdd(a, a, b)
+	check("dst equals lhs", func(a *Fr, b *Fr) bool {
+		FrAdd(a, a, b)
 		return a.IsEqual(aPlusB)
 	})
-	check("dst equals rhs", func(a *gmcl.Fr, b *gmcl.Fr) bool {
-		gmcl.FrAdd(b, a, b)
+	check("dst equals rhs", func(a *Fr, b *Fr) bool {
+		FrAdd(b, a, b)
 		return b.IsEqual(aPlusB)
 	})
-	check("dst equals lhs and rhs", func(a *gmcl.Fr, b *gmcl.Fr) bool {
-		gmcl.FrAdd(a, a, a)
+	check("dst equals lhs and rhs", func(a *Fr, b *Fr) bool {
+		FrAdd(a, a, a)
 		return a.IsEqual(twoA)
 	})
 }
@@ -45,14 +42,14 @@ func TestInplaceAdd(t *testing.T) {
 func TestInplaceMul(t *testing.T) {
 	aVal := RandomFr()
 	bVal := RandomFr()
-	aMulB := new(gmcl.Fr)
-	gmcl.FrMul(aMulB, aVal, bVal)
-	squareA := new(gmcl.Fr)
-	gmcl.FrMul(squareA, aVal, aVal)
+	aMulB := new(Fr)
+	FrMul(aMulB, aVal, bVal)
+	squareA := new(Fr)
+	FrMul(squareA, aVal, aVal)
 
-	check := func(name string, fn func(a, b *gmcl.Fr) bool) {
+	check := func(name string, fn func(a, b *Fr) bool) {
 		t.Run(name, func(t *testing.T) {
-			var a, b mcl.Fr
+			var a, b Fr
 			CopyFr(&a, aVal)
 			CopyFr(&b, bVal)
 			if !fn(&a, &b) {
@@ -60,16 +57,16 @@ func TestInplaceMul(t *testing.T) {
 			}
 		})
 	}
-	check("dst equals lhs", func(a *gmcl.Fr, b *gmcl.Fr) bool {
-		gmcl.FrMul(a, a, b)
+	check("dst equals lhs", func(a *Fr, b *Fr) bool {
+		FrMul(a, a, b)
 		return a.IsEqual(aMulB)
 	})
-	check("dst equals rhs", func(a *gmcl.Fr, b *gmcl.Fr) bool {
-		gmcl.FrMul(b, a, b)
+	check("dst equals rhs", func(a *Fr, b *Fr) bool {
+		FrMul(b, a, b)
 		return b.IsEqual(aMulB)
 	})
-	check("dst equals lhs and rhs", func(a *gmcl.Fr, b *gmcl.Fr) bool {
-		gmcl.FrMul(a, a, a)
+	check("dst equals lhs and rhs", func(a *Fr, b *Fr) bool {
+		FrMul(a, a, a)
 		return a.IsEqual(squareA)
 	})
 }
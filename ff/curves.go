@@ -0,0 +1,101 @@
+package ff
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// Pairing-friendly curve whose scalar field is used as Fr.
+type Curve int
+
+const (
+	BLS12_381 Curve = iota
+	// The alt_bn128 curve of the Ethereum precompiles.
+	BN254
+)
+
+// Parameters of the scalar field of a curve.
+type curveParams struct {
+	// Name of the curve for mcl
+	name string
+	// MODULUS, decimal
+	modulus string
+	// Multiplicative generator, outside of every subgroup of roots of unity
+	primitiveRoot int64
+	// Scale2RootOfUnity, computed once the backend is initialized
+	rootsOfUnity func() []Fr
+}
+
+var curves = map[Curve]*curveParams{
+	BLS12_381: {
+		name:          "bls12-381",
+		modulus:       "52435875175126190479447740508185965837690552500527637822603658699938581184513",
+		primitiveRoot: 5,
+		rootsOfUnity:  bls12381RootsOfUnity,
+	},
+	BN254: {
+		name:          "bn254_snark",
+		modulus:       "21888242871839275222246405745257275088548364400416034343698204186575808495617",
+		primitiveRoot: 5,
+		rootsOfUnity:  bn254RootsOfUnity,
+	},
+}
+
+var (
+	currentCurve Curve
+	// Modulus of the selected curve, big-endian
+	modulusBytes [FrBytes]byte
+)
+
+func init() {
+	if err := InitCurve(BLS12_381); err != nil {
+		panic(err)
+	}
+}
+
+func (c Curve) String() string {
+	switch c {
+	case BLS12_381:
+		return "BLS12-381"
+	case BN254:
+		return "BN254"
+	}
+	return fmt.Sprintf("Curve(%d)", int(c))
+}
+
+// Selects the curve whose scalar field is used as Fr. BLS12-381 is selected by default.
+// Must be called before any other use of the package and its dependents: field elements,
+// FFT settings and anything else computed under the previous curve are meaningless afterwards.
+// Not safe for concurrent use.
+func InitCurve(c Curve) error {
+	params, ok := curves[c]
+	if !ok {
+		return fmt.Errorf("unknown curve %s", c)
+	}
+	modulus, ok := new(big.Int).SetString(params.modulus, 10)
+	if !ok {
+		return fmt.Errorf("invalid modulus for curve %s", c)
+	}
+	if err := initBackend(c, modulus); err != nil {
+		return err
+	}
+	currentCurve = c
+	modulus.FillBytes(modulusBytes[:])
+	initGlobals(params)
+
+	if MODULUS_MINUS1.GetString(10) != new(big.Int).Sub(modulus, big.NewInt(1)).String() {
+		return fmt.Errorf("backend disagrees on the modulus of curve %s", c)
+	}
+	return nil
+}
+
+// Returns the selected curve.
+func CurrentCurve() Curve {
+	return currentCurve
+}
+
+// Returns the largest k s.t. Fr of the selected curve has primitive 2^k-th roots of unity.
+// FFTs are limited to 2^TwoAdicity() values.
+func TwoAdicity() uint8 {
+	return uint8(len(Scale2RootOfUnity) - 1)
+}
@@ -0,0 +1,66 @@
+package ff
+
+import (
+	"math/big"
+	"testing"
+)
+
+// Runs fn with c selected, restoring the default curve afterwards.
+func withCurve(t *testing.T, c Curve, fn func(t *testing.T)) {
+	t.Run(c.String(), func(t *testing.T) {
+		if err := InitCurve(c); err != nil {
+			t.Fatalf("InitCurve(%s): %v", c, err)
+		}
+		defer func() {
+			if err := InitCurve(BLS12_381); err != nil {
+				panic(err)
+			}
+		}()
+		fn(t)
+	})
+}
+
+func TestInitCurve(t *testing.T) {
+	var tests = []struct {
+		curve      Curve
+		twoAdicity uint8
+		modulus    string
+	}{
+		{BLS12_381, 32, "52435875175126190479447740508185965837690552500527637822603658699938581184513"},
+		{BN254, 28, "21888242871839275222246405745257275088548364400416034343698204186575808495617"},
+	}
+
+	for _, tt := range tests {
+		withCurve(t, tt.curve, func(t *testing.T) {
+			if CurrentCurve() != tt.curve {
+				t.Errorf("CurrentCurve: expected %s, got %s", tt.curve, CurrentCurve())
+			}
+			if TwoAdicity() != tt.twoAdicity {
+				t.Errorf("TwoAdicity: expected %d, got %d", tt.twoAdicity, TwoAdicity())
+			}
+			// 2^TwoAdicity must divide r - 1
+			r, _ := new(big.Int).SetString(tt.modulus, 10)
+			rMinus1 := new(big.Int).Sub(r, big.NewInt(1))
+			if MODULUS_MINUS1.GetString(10) != rMinus1.String() {
+				t.Errorf("MODULUS_MINUS1: expected %s, got %s", rMinus1, MODULUS_MINUS1.GetString(10))
+			}
+			if rMinus1.TrailingZeroBits() != uint(tt.twoAdicity) {
+				t.Errorf("TwoAdicity: r - 1 has %d trailing zero bits", rMinus1.TrailingZeroBits())
+			}
+			// Arithmetic wraps around the modulus of the curve
+			var x, y Fr
+			x.SetInt64(1)
+			FrAdd(&y, &MODULUS_MINUS1, &x)
+			if y.IsZero() == false {
+				t.Errorf("FrAdd: (r - 1) + 1 must be zero")
+			}
+		})
+	}
+
+	if err := InitCurve(Curve(-1)); err == nil {
+		t.Errorf("InitCurve: expected an error for an unknown curve")
+	}
+	if CurrentCurve() != BLS12_381 {
+		t.Errorf("InitCurve: a failed call must keep the selected curve")
+	}
+}
@@ -0,0 +1,92 @@
+package ff
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// Size of an encoded Fr. The moduli of all supported curves are below 2^256.
+const FrBytes = 32
+
+// Byte order of an encoded Fr.
+type ByteOrder int
+
+const (
+	LittleEndian ByteOrder = iota
+	BigEndian
+)
+
+var (
+	// Returned when decoding a value that is not below the modulus.
+	ErrNonCanonical = errors.New("value is not below the modulus")
+	// Returned when decoding an input of the wrong length.
+	ErrInvalidLength = errors.New("invalid length")
+)
+
+func (o ByteOrder) String() string {
+	switch o {
+	case LittleEndian:
+		return "little-endian"
+	case BigEndian:
+		return "big-endian"
+	}
+	return fmt.Sprintf("ByteOrder(%d)", int(o))
+}
+
+// Reverses b in place
+func reverseBytes(b []byte) {
+	for i, j := 0, len(b)-1; i < j; i, j = i+1, j-1 {
+		b[i], b[j] = b[j], b[i]
+	}
+}
+
+// Encodes src as FrBytes bytes in the given order.
+// The encoding is canonical: the value is always below the modulus.
+func FrToBytes(src *Fr, order ByteOrder) [FrBytes]byte {
+	v := frToBytesBE(src)
+	if order == LittleEndian {
+		reverseBytes(v[:])
+	}
+	return v
+}
+
+// Decodes FrBytes bytes in the given order into dst.
+// Returns ErrInvalidLength if b is not FrBytes long and ErrNonCanonical if the value is not
+// below the modulus, dst is left unchanged then.
+func FrFromBytes(dst *Fr, b []byte, order ByteOrder) error {
+	if len(b) != FrBytes {
+		return fmt.Errorf("FrFromBytes: %w: expected %d bytes, got %d", ErrInvalidLength, FrBytes, len(b))
+	}
+	var v [FrBytes]byte
+	copy(v[:], b)
+	if order == LittleEndian {
+		reverseBytes(v[:])
+	}
+	if bytes.Compare(v[:], modulusBytes[:]) >= 0 {
+		return fmt.Errorf("FrFromBytes: %w", ErrNonCanonical)
+	}
+	frSetBytesBE(dst, &v)
+	return nil
+}
+
+// Same as FrFromBytes, but reduces the value mod r instead of rejecting it.
+// b may have any length, e.g. 64 bytes of a hash for a close to uniform result.
+func FrFromBytesMod(dst *Fr, b []byte, order ByteOrder) {
+	buf := make([]byte, len(b))
+	copy(buf, b)
+	if order == LittleEndian {
+		reverseBytes(buf)
+	}
+	x := new(big.Int).SetBytes(buf)
+	x.Mod(x, new(big.Int).SetBytes(modulusBytes[:]))
+	var v [FrBytes]byte
+	x.FillBytes(v[:])
+	frSetBytesBE(dst, &v)
+}
+
+// Inverse of FrTo32: decodes 32 little-endian bytes, rejecting values not below the modulus.
+func FrFrom32(dst *Fr, v [32]byte) error {
+	return FrFromBytes(dst, v[:], LittleEndian)
+}
@@ -0,0 +1,131 @@
+package ff
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"testing"
+)
+
+func TestFrBytesRoundtrip(t *testing.T) {
+	for _, c := range []Curve{BLS12_381, BN254} {
+		withCurve(t, c, func(t *testing.T) {
+			values := append([]Fr{ZERO, ONE, MODULUS_MINUS1}, Scale2RootOfUnity...)
+			for i := range values {
+				x := &values[i]
+				t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+					want := new(big.Int)
+					want.SetString(x.GetString(10), 10)
+
+					for _, order := range []ByteOrder{LittleEndian, BigEndian} {
+						v := FrToBytes(x, order)
+						be := v
+						if order == LittleEndian {
+							reverseBytes(be[:])
+						}
+						if new(big.Int).SetBytes(be[:]).Cmp(want) != 0 {
+							t.Errorf("FrToBytes %s: got %x, expected %s", order, v, want)
+						}
+
+						var y Fr
+						if err := FrFromBytes(&y, v[:], order); err != nil {
+							t.Fatalf("FrFromBytes %s: %v", order, err)
+						}
+						if y.IsEqual(x) == false {
+							t.Errorf("FrFromBytes %s: roundtrip did not match", order)
+						}
+						FrFromBytesMod(&y, v[:], order)
+						if y.IsEqual(x) == false {
+							t.Errorf("FrFromBytesMod %s: roundtrip did not match", order)
+						}
+					}
+
+					// FrTo32 is the little-endian encoding
+					var y Fr
+					if v := FrTo32(x); v != FrToBytes(x, LittleEndian) {
+						t.Errorf("FrTo32: did not match FrToBytes")
+					} else if err := FrFrom32(&y, v); err != nil || y.IsEqual(x) == false {
+						t.Errorf("FrFrom32: roundtrip did not match, %v", err)
+					}
+				})
+			}
+		})
+	}
+}
+
+func TestFrFromBytesNonCanonical(t *testing.T) {
+	for _, c := range []Curve{BLS12_381, BN254} {
+		withCurve(t, c, func(t *testing.T) {
+			r := new(big.Int).SetBytes(modulusBytes[:])
+			maxValue := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 8*FrBytes), big.NewInt(1))
+
+			tests := []*big.Int{
+				r,
+				new(big.Int).Add(r, big.NewInt(5)),
+				new(big.Int).Add(new(big.Int).Lsh(r, 1), big.NewInt(1)),
+				maxValue,
+			}
+
+			for counter, value := range tests {
+				t.Run(fmt.Sprintf("%d", counter+1), func(t *testing.T) {
+					var be [FrBytes]byte
+					value.FillBytes(be[:])
+					le := be
+					reverseBytes(le[:])
+
+					var want Fr
+					SetFr(&want, new(big.Int).Mod(value, r).String())
+
+					for _, enc := range []struct {
+						order ByteOrder
+						v     [FrBytes]byte
+					}{{BigEndian, be}, {LittleEndian, le}} {
+						y := ONE
+						if err := FrFromBytes(&y, enc.v[:], enc.order); errors.Is(err, ErrNonCanonical) == false {
+							t.Errorf("FrFromBytes %s: expected %v, got %v", enc.order, ErrNonCanonical, err)
+						}
+						if y.IsOne() == false {
+							t.Errorf("FrFromBytes %s: dst must be unchanged on error", enc.order)
+						}
+						FrFromBytesMod(&y, enc.v[:], enc.order)
+						if y.IsEqual(&want) == false {
+							t.Errorf("FrFromBytesMod %s: got %s, expected %s", enc.order, y.GetString(10), want.GetString(10))
+						}
+					}
+				})
+			}
+		})
+	}
+}
+
+func TestFrFromBytesLength(t *testing.T) {
+	var x Fr
+	for _, n := range []int{0, 31, 33, 64} {
+		if err := FrFromBytes(&x, make([]byte, n), LittleEndian); errors.Is(err, ErrInvalidLength) == false {
+			t.Errorf("FrFromBytes: expected %v for %d bytes, got %v", ErrInvalidLength, n, err)
+		}
+	}
+
+	// Wide inputs are reduced, e.g. the 64 bytes of a hash
+	wide := make([]byte, 64)
+	for i := range wide {
+		wide[i] = byte(i + 1)
+	}
+	r := new(big.Int).SetBytes(modulusBytes[:])
+	for _, order := range []ByteOrder{LittleEndian, BigEndian} {
+		be := make([]byte, len(wide))
+		copy(be, wide)
+		if order == LittleEndian {
+			reverseBytes(be)
+		}
+		want := new(big.Int).Mod(new(big.Int).SetBytes(be), r)
+		FrFromBytesMod(&x, wide, order)
+		if x.GetString(10) != want.String() {
+			t.Errorf("FrFromBytesMod %s: got %s, expected %s", order, x.GetString(10), want)
+		}
+	}
+	FrFromBytesMod(&x, nil, BigEndian)
+	if x.IsZero() == false {
+		t.Errorf("FrFromBytesMod: expected zero for an empty input")
+	}
+}
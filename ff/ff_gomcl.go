@@ -19,7 +19,27 @@ var ZeroG1 gmcl.G1
 var ZeroG2 gmcl.G2
 
 // Herumi BLS doesn't offer these points to us, so we have to work around it by declaring them ourselves.
-func initG1G2() {
+func initG1G2(c Curve) {
+	switch c {
+	case BN254:
+		initG1G2BN254()
+	default:
+		initG1G2BLS12381()
+	}
+
+	ZeroG1.X.SetInt64(1)
+	ZeroG1.Y.SetInt64(1)
+	ZeroG1.Z.SetInt64(0)
+
+	ZeroG2.X.D[0].SetInt64(1)
+	ZeroG2.X.D[1].SetInt64(0)
+	ZeroG2.Y.D[0].SetInt64(1)
+	ZeroG2.Y.D[1].SetInt64(0)
+	ZeroG2.Z.D[0].SetInt64(0)
+	ZeroG2.Z.D[1].SetInt64(0)
+}
+
+func initG1G2BLS12381() {
 	GenG1.X.SetString("3685416753713387016781088315183077757961620795782546409894578378688607592378376318836054947676345821548104185464507", 10)
 	GenG1.Y.SetString("1339506544944476473020471379941921221584933875938349620426543736416511423956333506472724655353366534992391756441569", 10)
 	GenG1.Z.SetInt64(1)
@@ -30,17 +50,20 @@ func initG1G2() {
 	GenG2.Y.D[1].SetString("927553665492332455747201965776037880757740193453592970025027978793976877002675564980949289727957565575433344219582", 10)
 	GenG2.Z.D[0].SetInt64(1)
 	GenG2.Z.D[1].Clear()
+}
 
-	ZeroG1.X.SetInt64(1)
-	ZeroG1.Y.SetInt64(1)
-	ZeroG1.Z.SetInt64(0)
+// Generators of the Ethereum precompiles (EIP-196, EIP-197)
+func initG1G2BN254() {
+	GenG1.X.SetInt64(1)
+	GenG1.Y.SetInt64(2)
+	GenG1.Z.SetInt64(1)
 
-	ZeroG2.X.D[0].SetInt64(1)
-	ZeroG2.X.D[1].SetInt64(0)
-	ZeroG2.Y.D[0].SetInt64(1)
-	ZeroG2.Y.D[1].SetInt64(0)
-	ZeroG2.Z.D[0].SetInt64(0)
-	ZeroG2.Z.D[1].SetInt64(0)
+	GenG2.X.D[0].SetString("10857046999023057135944570762232829481370756359578518086990519993285655852781", 10)
+	GenG2.X.D[1].SetString("11559732032986387107991004021392285783925812861821192530917403151452391805634", 10)
+	GenG2.Y.D[0].SetString("8495653923123431417604973247489272438418190587263600148770280649306958101930", 10)
+	GenG2.Y.D[1].SetString("4082367875863433681332203403145435568316851327593401208105741076214120093531", 10)
+	GenG2.Z.D[0].SetInt64(1)
+	GenG2.Z.D[1].Clear()
 }
 
 func CopyG1(dst *gmcl.G1, v *gmcl.G1) {
@@ -74,6 +97,18 @@ func PairingsVerify(a1 *gmcl.G1, a2 *gmcl.G2, b1 *gmcl.G1, b2 *gmcl.G2) bool {
 
 }
 
+// \prod_i e(P_i, Q_i) = 1_T, with one Miller loop over all pairs and one final exponentiation.
+// Panics if the lengths differ.
+func PairingProductIsOne(P []gmcl.G1, Q []gmcl.G2) bool {
+	if len(P) != len(Q) {
+		panic(fmt.Sprintf("PairingProductIsOne: Got %d points in G1 but %d in G2", len(P), len(Q)))
+	}
+	var tmp gmcl.GT
+	gmcl.MillerLoopVec(&tmp, P, Q)
+	gmcl.FinalExp(&tmp, &tmp)
+	return tmp.IsOne()
+}
+
 func DebugG1s(msg string, values []gmcl.G1) {
 	var out strings.Builder
 	for i := range values {
@@ -0,0 +1,57 @@
+package ff
+
+// Helpers over the Fr type of whichever backend is selected by the bignum_* build tags.
+
+func SetFr(dst *Fr, v string) {
+	if err := dst.SetString(v, 10); err != nil {
+		panic(err)
+	}
+}
+
+func RandomFr() *Fr {
+	var out Fr
+	out.Random()
+	return &out
+}
+
+func CopyFr(dst *Fr, v *Fr) {
+	*dst = *v
+}
+
+func AsFr(dst *Fr, i uint64) {
+	dst.SetInt64(int64(i))
+}
+
+func FrStr(b *Fr) string {
+	if b == nil {
+		return "<nil>"
+	}
+	return b.GetString(10)
+}
+
+func IntAsFr(dst *Fr, i int64) {
+	dst.SetInt64(i)
+}
+
+func FromInt64Vec(in []int64) []Fr {
+	n := len(in)
+	dst := make([]Fr, n, n)
+	for i := 0; i < n; i++ {
+		(&dst[i]).SetInt64(in[i])
+	}
+	return dst
+}
+
+func MulVecFr(a, b []Fr) []Fr {
+
+	n := len(a)
+	if n == len(b) && n > 0 {
+		result := make([]Fr, n, n)
+		for i := 0; i < n; i++ {
+			FrMul(&result[i], &a[i], &b[i])
+		}
+		return result
+	}
+	result := make([]Fr, 0)
+	return result
+}
@@ -1,24 +1,26 @@
 package ff
 
-import gmcl "github.com/alinush/go-mcl"
+// Scale2RootOfUnity[k] is a primitive 2^k-th root of unity of the selected curve, for k up to TwoAdicity().
+var Scale2RootOfUnity []Fr
 
-var Scale2RootOfUnity []gmcl.Fr
+var ZERO, ONE, TWO Fr
+var MODULUS_MINUS1, MODULUS_MINUS1_DIV2, MODULUS_MINUS2 Fr
+var INVERSE_TWO Fr
 
-var ZERO, ONE, TWO gmcl.Fr
-var MODULUS_MINUS1, MODULUS_MINUS1_DIV2, MODULUS_MINUS2 gmcl.Fr
-var INVERSE_TWO gmcl.Fr
+// Multiplicative generator of Fr, outside of every subgroup of roots of unity.
+var PRIMITIVE_ROOT Fr
 
-func ToFr(v string) (out gmcl.Fr) {
+func ToFr(v string) (out Fr) {
 	SetFr(&out, v)
 	return
 }
 
-func initGlobals() {
+func bls12381RootsOfUnity() []Fr {
 
 	// MODULUS = 52435875175126190479447740508185965837690552500527637822603658699938581184513
 	// PRIMITIVE_ROOT = 5
-	// [pow(PRIMITIVE_ROOT, (MODULUS - 1) // (2**i), MODULUS) for i in range(32)]
-	Scale2RootOfUnity = []gmcl.Fr{
+	// [pow(PRIMITIVE_ROOT, (MODULUS - 1) // (2**i), MODULUS) for i in range(33)]
+	return []Fr{
 		/* k=0          r=1          */ ToFr("1"),
 		/* k=1          r=2          */ ToFr("52435875175126190479447740508185965837690552500527637822603658699938581184512"),
 		/* k=2          r=4          */ ToFr("3465144826073652318776269530687742778270252468765361963008"),
@@ -51,16 +53,60 @@ func initGlobals() {
 		/* k=29         r=536870912  */ ToFr("50819341139666003587274541409207395600071402220052213520254526953892511091577"),
 		/* k=30         r=1073741824 */ ToFr("3811138593988695298394477416060533432572377403639180677141944665584601642504"),
 		/* k=31         r=2147483648 */ ToFr("43599901455287962219281063402626541872197057165786841304067502694013639882090"),
+		/* k=32         r=4294967296 */ ToFr("937917089079007706106976984802249742464848817460758522850752807661925904159"),
 	}
+}
+
+func bn254RootsOfUnity() []Fr {
+
+	// MODULUS = 21888242871839275222246405745257275088548364400416034343698204186575808495617
+	// PRIMITIVE_ROOT = 5
+	// [pow(PRIMITIVE_ROOT, (MODULUS - 1) // (2**i), MODULUS) for i in range(29)]
+	return []Fr{
+		/* k=0          r=1          */ ToFr("1"),
+		/* k=1          r=2          */ ToFr("21888242871839275222246405745257275088548364400416034343698204186575808495616"),
+		/* k=2          r=4          */ ToFr("21888242871839275217838484774961031246007050428528088939761107053157389710902"),
+		/* k=3          r=8          */ ToFr("19540430494807482326159819597004422086093766032135589407132600596362845576832"),
+		/* k=4          r=16         */ ToFr("14940766826517323942636479241147756311199852622225275649687664389641784935947"),
+		/* k=5          r=32         */ ToFr("4419234939496763621076330863786513495701855246241724391626358375488475697872"),
+		/* k=6          r=64         */ ToFr("9088801421649573101014283686030284801466796108869023335878462724291607593530"),
+		/* k=7          r=128        */ ToFr("10359452186428527605436343203440067497552205259388878191021578220384701716497"),
+		/* k=8          r=256        */ ToFr("3478517300119284901893091970156912948790432420133812234316178878452092729974"),
+		/* k=9          r=512        */ ToFr("6837567842312086091520287814181175430087169027974246751610506942214842701774"),
+		/* k=10         r=1024       */ ToFr("3161067157621608152362653341354432744960400845131437947728257924963983317266"),
+		/* k=11         r=2048       */ ToFr("1120550406532664055539694724667294622065367841900378087843176726913374367458"),
+		/* k=12         r=4096       */ ToFr("4158865282786404163413953114870269622875596290766033564087307867933865333818"),
+		/* k=13         r=8192       */ ToFr("197302210312744933010843010704445784068657690384188106020011018676818793232"),
+		/* k=14         r=16384      */ ToFr("20619701001583904760601357484951574588621083236087856586626117568842480512645"),
+		/* k=15         r=32768      */ ToFr("20402931748843538985151001264530049874871572933694634836567070693966133783803"),
+		/* k=16         r=65536      */ ToFr("421743594562400382753388642386256516545992082196004333756405989743524594615"),
+		/* k=17         r=131072     */ ToFr("12650941915662020058015862023665998998969191525479888727406889100124684769509"),
+		/* k=18         r=262144     */ ToFr("11699596668367776675346610687704220591435078791727316319397053191800576917728"),
+		/* k=19         r=524288     */ ToFr("15549849457946371566896172786938980432421851627449396898353380550861104573629"),
+		/* k=20         r=1048576    */ ToFr("17220337697351015657950521176323262483320249231368149235373741788599650842711"),
+		/* k=21         r=2097152    */ ToFr("13536764371732269273912573961853310557438878140379554347802702086337840854307"),
+		/* k=22         r=4194304    */ ToFr("12143866164239048021030917283424216263377309185099704096317235600302831912062"),
+		/* k=23         r=8388608    */ ToFr("934650972362265999028062457054462628285482693704334323590406443310927365533"),
+		/* k=24         r=16777216   */ ToFr("5709868443893258075976348696661355716898495876243883251619397131511003808859"),
+		/* k=25         r=33554432   */ ToFr("19200870435978225707111062059747084165650991997241425080699860725083300967194"),
+		/* k=26         r=67108864   */ ToFr("7419588552507395652481651088034484897579724952953562618697845598160172257810"),
+		/* k=27         r=134217728  */ ToFr("2082940218526944230311718225077035922214683169814847712455127909555749686340"),
+		/* k=28         r=268435456  */ ToFr("19103219067921713944291392827692070036145651957329286315305642004821462161904"),
+	}
+}
+
+func initGlobals(c *curveParams) {
+	Scale2RootOfUnity = c.rootsOfUnity()
 
 	ZERO.SetInt64(int64(0))
 	ONE.SetInt64(int64(1))
 	TWO.SetInt64(int64(2))
+	PRIMITIVE_ROOT.SetInt64(c.primitiveRoot)
 
-	gmcl.FrSub(&MODULUS_MINUS1, &ZERO, &ONE)
-	gmcl.FrDiv(&MODULUS_MINUS1_DIV2, &MODULUS_MINUS1, &TWO)
-	gmcl.FrSub(&MODULUS_MINUS2, &ZERO, &TWO)
-	gmcl.FrInv(&INVERSE_TWO, &TWO)
+	FrSub(&MODULUS_MINUS1, &ZERO, &ONE)
+	FrDiv(&MODULUS_MINUS1_DIV2, &MODULUS_MINUS1, &TWO)
+	FrSub(&MODULUS_MINUS2, &ZERO, &TWO)
+	FrInv(&INVERSE_TWO, &TWO)
 }
 
 func IsPowerOfTwo(v uint64) bool {
@@ -0,0 +1,26 @@
+package ff
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestScale2RootOfUnity(t *testing.T) {
+	for _, c := range []Curve{BLS12_381, BN254} {
+		withCurve(t, c, func(t *testing.T) {
+			for k := 1; k < len(Scale2RootOfUnity); k++ {
+				t.Run(fmt.Sprintf("k=%d", k), func(t *testing.T) {
+					// r^(2^(k-1)) must be -1, so r has order exactly 2^k
+					var x Fr
+					CopyFr(&x, &Scale2RootOfUnity[k])
+					for i := 1; i < k; i++ {
+						FrMul(&x, &x, &x)
+					}
+					if x.IsEqual(&MODULUS_MINUS1) == false {
+						t.Errorf("root of unity has the wrong order")
+					}
+				})
+			}
+		})
+	}
+}
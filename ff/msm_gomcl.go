@@ -0,0 +1,231 @@
+//go:build !bignum_pure && !bignum_hol256 && !bignum_kilic && !bignum_hbls
+// +build !bignum_pure,!bignum_hol256,!bignum_kilic,!bignum_hbls
+
+package ff
+
+import (
+	"fmt"
+	"math/bits"
+	"sync"
+
+	gmcl "github.com/alinush/go-mcl"
+)
+
+// Multi-scalar multiplication \sum_i scalars_i * bases_i with Pippenger's bucket method:
+// scalars are cut into windows of c bits, and per window each base is added to the bucket of its digit,
+// so the cost is about 256/c * (n + 2^(c+1)) additions instead of 256 doublings and additions per base.
+
+// Below this many bases the buckets do not pay off, scalar multiplications are cheaper.
+const msmNaiveThreshold = 8
+
+// Below this many bases per worker, MSMG1Parallel and MSMG2Parallel do not spawn goroutines.
+const msmParallelThreshold = 1 << 8
+
+// Returns the window size in bits for n bases, about log2(n) - 2 which balances
+// the n additions into buckets against the 2^(c+1) additions summing them.
+func msmWindowBits(n int) uint {
+	c := bits.Len(uint(n))
+	switch {
+	case c < 5:
+		return 2
+	case c > 18:
+		return 16
+	}
+	return uint(c - 2)
+}
+
+// Scalars cut into windows of c bits, the lowest window first
+type msmScalars struct {
+	b       [][FrBytes]byte
+	c       uint
+	windows int
+}
+
+func newMSMScalars(scalars []Fr, c uint) *msmScalars {
+	s := &msmScalars{b: make([][FrBytes]byte, len(scalars)), c: c, windows: int((8*FrBytes + c - 1) / c)}
+	for i := range scalars {
+		s.b[i] = FrToBytes(&scalars[i], LittleEndian)
+	}
+	return s
+}
+
+// Returns the digit of scalar i in window w
+func (s *msmScalars) digit(i int, w int) uint32 {
+	off := uint(w) * s.c
+	b := &s.b[i]
+	var v uint32
+	// c <= 16 bits starting anywhere in a byte span at most 3 bytes
+	for k := uint(0); k < 3 && off/8+k < FrBytes; k++ {
+		v |= uint32(b[off/8+k]) << (8 * k)
+	}
+	return (v >> (off % 8)) & (1<<s.c - 1)
+}
+
+func checkMSMLengths(name string, bases int, scalars int) {
+	if bases != scalars {
+		panic(fmt.Sprintf("%s: Got %d bases but %d scalars", name, bases, scalars))
+	}
+}
+
+// Computes dst = \sum_i scalars_i * bases_i. Panics if the lengths differ.
+func MSMG1(dst *gmcl.G1, bases []gmcl.G1, scalars []Fr) {
+	checkMSMLengths("MSMG1", len(bases), len(scalars))
+	msmG1(dst, bases, scalars)
+}
+
+// Same as MSMG1, splitting the bases among up to workers goroutines. Results do not depend on workers.
+func MSMG1Parallel(dst *gmcl.G1, bases []gmcl.G1, scalars []Fr, workers int) {
+	checkMSMLengths("MSMG1Parallel", len(bases), len(scalars))
+	n := len(bases)
+	if maxWorkers := n / msmParallelThreshold; workers > maxWorkers {
+		workers = maxWorkers
+	}
+	if workers <= 1 {
+		msmG1(dst, bases, scalars)
+		return
+	}
+
+	chunk := (n + workers - 1) / workers
+	partial := make([]gmcl.G1, (n+chunk-1)/chunk)
+	var wg sync.WaitGroup
+	for k := range partial {
+		start, end := k*chunk, (k+1)*chunk
+		if end > n {
+			end = n
+		}
+		wg.Add(1)
+		go func(k, start, end int) {
+			defer wg.Done()
+			msmG1(&partial[k], bases[start:end], scalars[start:end])
+		}(k, start, end)
+	}
+	wg.Wait()
+
+	dst.Clear()
+	for k := range partial {
+		gmcl.G1Add(dst, dst, &partial[k])
+	}
+}
+
+func msmG1(dst *gmcl.G1, bases []gmcl.G1, scalars []Fr) {
+	var res gmcl.G1
+	res.Clear()
+	if len(bases) < msmNaiveThreshold {
+		var tmp gmcl.G1
+		for i := range bases {
+			gmcl.G1Mul(&tmp, &bases[i], &scalars[i])
+			gmcl.G1Add(&res, &res, &tmp)
+		}
+		*dst = res
+		return
+	}
+
+	s := newMSMScalars(scalars, msmWindowBits(len(bases)))
+	buckets := make([]gmcl.G1, 1<<s.c-1)
+	var sum, acc gmcl.G1
+	for w := s.windows - 1; w >= 0; w-- {
+		for j := uint(0); j < s.c; j++ {
+			gmcl.G1Dbl(&res, &res)
+		}
+
+		for j := range buckets {
+			buckets[j].Clear()
+		}
+		for i := range bases {
+			if d := s.digit(i, w); d != 0 {
+				gmcl.G1Add(&buckets[d-1], &buckets[d-1], &bases[i])
+			}
+		}
+
+		// \sum_d d * bucket_d, as a sum of running sums from the top bucket down
+		sum.Clear()
+		acc.Clear()
+		for j := len(buckets) - 1; j >= 0; j-- {
+			gmcl.G1Add(&sum, &sum, &buckets[j])
+			gmcl.G1Add(&acc, &acc, &sum)
+		}
+		gmcl.G1Add(&res, &res, &acc)
+	}
+	*dst = res
+}
+
+// Computes dst = \sum_i scalars_i * bases_i. Panics if the lengths differ.
+func MSMG2(dst *gmcl.G2, bases []gmcl.G2, scalars []Fr) {
+	checkMSMLengths("MSMG2", len(bases), len(scalars))
+	msmG2(dst, bases, scalars)
+}
+
+// Same as MSMG2, splitting the bases among up to workers goroutines. Results do not depend on workers.
+func MSMG2Parallel(dst *gmcl.G2, bases []gmcl.G2, scalars []Fr, workers int) {
+	checkMSMLengths("MSMG2Parallel", len(bases), len(scalars))
+	n := len(bases)
+	if maxWorkers := n / msmParallelThreshold; workers > maxWorkers {
+		workers = maxWorkers
+	}
+	if workers <= 1 {
+		msmG2(dst, bases, scalars)
+		return
+	}
+
+	chunk := (n + workers - 1) / workers
+	partial := make([]gmcl.G2, (n+chunk-1)/chunk)
+	var wg sync.WaitGroup
+	for k := range partial {
+		start, end := k*chunk, (k+1)*chunk
+		if end > n {
+			end = n
+		}
+		wg.Add(1)
+		go func(k, start, end int) {
+			defer wg.Done()
+			msmG2(&partial[k], bases[start:end], scalars[start:end])
+		}(k, start, end)
+	}
+	wg.Wait()
+
+	dst.Clear()
+	for k := range partial {
+		gmcl.G2Add(dst, dst, &partial[k])
+	}
+}
+
+func msmG2(dst *gmcl.G2, bases []gmcl.G2, scalars []Fr) {
+	var res gmcl.G2
+	res.Clear()
+	if len(bases) < msmNaiveThreshold {
+		var tmp gmcl.G2
+		for i := range bases {
+			gmcl.G2Mul(&tmp, &bases[i], &scalars[i])
+			gmcl.G2Add(&res, &res, &tmp)
+		}
+		*dst = res
+		return
+	}
+
+	s := newMSMScalars(scalars, msmWindowBits(len(bases)))
+	buckets := make([]gmcl.G2, 1<<s.c-1)
+	var sum, acc gmcl.G2
+	for w := s.windows - 1; w >= 0; w-- {
+		for j := uint(0); j < s.c; j++ {
+			gmcl.G2Dbl(&res, &res)
+		}
+
+		for j := range buckets {
+			buckets[j].Clear()
+		}
+		for i := range bases {
+			if d := s.digit(i, w); d != 0 {
+				gmcl.G2Add(&buckets[d-1], &buckets[d-1], &bases[i])
+			}
+		}
+
+		sum.Clear()
+		acc.Clear()
+		for j := len(buckets) - 1; j >= 0; j-- {
+			gmcl.G2Add(&sum, &sum, &buckets[j])
+			gmcl.G2Add(&acc, &acc, &sum)
+		}
+		gmcl.G2Add(&res, &res, &acc)
+	}
+	*dst = res
+}
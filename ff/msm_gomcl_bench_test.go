@@ -0,0 +1,40 @@
+//go:build !bignum_pure && !bignum_hol256 && !bignum_kilic && !bignum_hbls
+// +build !bignum_pure,!bignum_hol256,!bignum_kilic,!bignum_hbls
+
+package ff
+
+import (
+	"fmt"
+	"runtime"
+	"testing"
+
+	gmcl "github.com/alinush/go-mcl"
+)
+
+func BenchmarkMSMG1(b *testing.B) {
+	for _, n := range []int{1 << 6, 1 << 10, 1 << 14} {
+		bases, _, scalars := msmTestInputs(n)
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			var dst gmcl.G1
+			for i := 0; i < b.N; i++ {
+				MSMG1(&dst, bases, scalars)
+			}
+		})
+		b.Run(fmt.Sprintf("n=%d/parallel", n), func(b *testing.B) {
+			var dst gmcl.G1
+			for i := 0; i < b.N; i++ {
+				MSMG1Parallel(&dst, bases, scalars, runtime.GOMAXPROCS(0))
+			}
+		})
+		b.Run(fmt.Sprintf("n=%d/naive", n), func(b *testing.B) {
+			var dst, tmp gmcl.G1
+			for i := 0; i < b.N; i++ {
+				dst.Clear()
+				for j := range bases {
+					gmcl.G1Mul(&tmp, &bases[j], &scalars[j])
+					gmcl.G1Add(&dst, &dst, &tmp)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,109 @@
+//go:build !bignum_pure && !bignum_hol256 && !bignum_kilic && !bignum_hbls
+// +build !bignum_pure,!bignum_hol256,!bignum_kilic,!bignum_hbls
+
+package ff
+
+import (
+	"fmt"
+	"testing"
+
+	gmcl "github.com/alinush/go-mcl"
+)
+
+// Random multiples of the generators, with some special scalars and points mixed in
+func msmTestInputs(n int) ([]gmcl.G1, []gmcl.G2, []Fr) {
+	g1 := make([]gmcl.G1, n)
+	g2 := make([]gmcl.G2, n)
+	scalars := make([]Fr, n)
+	for i := 0; i < n; i++ {
+		gmcl.G1Mul(&g1[i], &GenG1, RandomFr())
+		gmcl.G2Mul(&g2[i], &GenG2, RandomFr())
+		switch i % 7 {
+		case 1:
+			scalars[i].Clear()
+		case 2:
+			scalars[i] = MODULUS_MINUS1
+		case 3:
+			scalars[i].SetInt64(1)
+		case 4:
+			g1[i].Clear()
+			g2[i].Clear()
+			scalars[i] = *RandomFr()
+		default:
+			scalars[i] = *RandomFr()
+		}
+	}
+	return g1, g2, scalars
+}
+
+func TestMSM(t *testing.T) {
+	for _, c := range []Curve{BLS12_381, BN254} {
+		withCurve(t, c, func(t *testing.T) {
+			for _, n := range []int{0, 1, 7, 8, 33, 300, 1100} {
+				t.Run(fmt.Sprintf("n=%d", n), func(t *testing.T) {
+					g1, g2, scalars := msmTestInputs(n)
+
+					var want1, tmp1 gmcl.G1
+					var want2, tmp2 gmcl.G2
+					want1.Clear()
+					want2.Clear()
+					for i := 0; i < n; i++ {
+						gmcl.G1Mul(&tmp1, &g1[i], &scalars[i])
+						gmcl.G1Add(&want1, &want1, &tmp1)
+						gmcl.G2Mul(&tmp2, &g2[i], &scalars[i])
+						gmcl.G2Add(&want2, &want2, &tmp2)
+					}
+
+					var got1 gmcl.G1
+					var got2 gmcl.G2
+					MSMG1(&got1, g1, scalars)
+					if got1.IsEqual(&want1) == false {
+						t.Errorf("MSMG1: did not match with the naive sum")
+					}
+					MSMG2(&got2, g2, scalars)
+					if got2.IsEqual(&want2) == false {
+						t.Errorf("MSMG2: did not match with the naive sum")
+					}
+					for _, workers := range []int{2, 4} {
+						MSMG1Parallel(&got1, g1, scalars, workers)
+						if got1.IsEqual(&want1) == false {
+							t.Errorf("MSMG1Parallel-%d: did not match with the naive sum", workers)
+						}
+						MSMG2Parallel(&got2, g2, scalars, workers)
+						if got2.IsEqual(&want2) == false {
+							t.Errorf("MSMG2Parallel-%d: did not match with the naive sum", workers)
+						}
+					}
+				})
+			}
+		})
+	}
+}
+
+func TestMSMWindowDigits(t *testing.T) {
+	// Every window size must cut a scalar into digits that add back up to it
+	x := *RandomFr()
+	for c := uint(1); c <= 16; c++ {
+		s := newMSMScalars([]Fr{x}, c)
+		var got, two, d Fr
+		two.SetInt64(int64(1) << c)
+		for w := s.windows - 1; w >= 0; w-- {
+			FrMul(&got, &got, &two)
+			d.SetInt64(int64(s.digit(0, w)))
+			FrAdd(&got, &got, &d)
+		}
+		if got.IsEqual(&x) == false {
+			t.Errorf("digits of %d bits did not add up to the scalar", c)
+		}
+	}
+}
+
+func TestMSMLengthMismatch(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("MSMG1: expected a panic on a length mismatch")
+		}
+	}()
+	var dst gmcl.G1
+	MSMG1(&dst, make([]gmcl.G1, 2), make([]Fr, 3))
+}
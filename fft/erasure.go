@@ -0,0 +1,111 @@
+package fft
+
+import (
+	"fmt"
+
+	"github.com/accumulators-agg/go-poly/ff"
+)
+
+// Reed-Solomon erasure coding over the roots of unity: n values are read as the evaluations of
+// a polynomial of degree < n over the n-th roots of unity, and extended to its evaluations over
+// the 2n-th roots. Any n of the 2n values determine the polynomial, so up to n of them may be lost.
+
+// Extends n values (a power of two) to 2n: inverse FFT to the coefficients, zero padding to 2n,
+// then FFT over the 2n-th roots of unity. As w_2n^2i = w_n^i, the data are the even entries of the result.
+// fs must have at least 2n roots of unity.
+func (fs *FFTSettings) ExtendData(data []ff.Fr) ([]ff.Fr, error) {
+	n := uint64(len(data))
+	if n == 0 {
+		return nil, fmt.Errorf("ExtendData: %w", ErrEmptyInput)
+	}
+	if !ff.IsPowerOfTwo(n) {
+		return nil, fmt.Errorf("ExtendData: %w: got %d values", ErrNotPowerOfTwo, n)
+	}
+	fs, err := fs.forWidth(2 * n)
+	if err != nil {
+		return nil, fmt.Errorf("ExtendData: %w", err)
+	}
+
+	coeffs, err := fs.FFT(data, true)
+	if err != nil {
+		return nil, fmt.Errorf("ExtendData: %w", err)
+	}
+	padded := make([]ff.Fr, 2*n, 2*n)
+	copy(padded, coeffs)
+	out, err := fs.FFT(padded, false)
+	if err != nil {
+		return nil, fmt.Errorf("ExtendData: %w", err)
+	}
+	return out, nil
+}
+
+// Recovers all 2n values extended by ExtendData from the samples, nil where a value is missing.
+// With E the polynomial to recover and Z the zero polynomial of the missing indices,
+// E * Z is known over the whole domain: interpolate it, divide by Z over a coset,
+// where Z has no roots, and interpolate E back.
+// Returns ErrTooManyErasures if more than half of the samples are missing, and
+// ErrNotCodeword if the known samples do not come from a polynomial of degree < n.
+func (fs *FFTSettings) RecoverData(samples []*ff.Fr) ([]ff.Fr, error) {
+	width := uint64(len(samples))
+	if width < 2 || !ff.IsPowerOfTwo(width) {
+		return nil, fmt.Errorf("RecoverData: %w: got %d samples", ErrNotPowerOfTwo, width)
+	}
+	fs, err := fs.forWidth(width)
+	if err != nil {
+		return nil, fmt.Errorf("RecoverData: %w", err)
+	}
+	var missing []uint64
+	for i, s := range samples {
+		if s == nil {
+			missing = append(missing, uint64(i))
+		}
+	}
+	if uint64(len(missing)) > width/2 {
+		return nil, fmt.Errorf("RecoverData: %w: %d of %d samples are missing", ErrTooManyErasures, len(missing), width)
+	}
+
+	zeroPoly, zeroEvals, err := fs.ZeroPolyViaMultiplication(missing, width)
+	if err != nil {
+		return nil, fmt.Errorf("RecoverData: %w", err)
+	}
+
+	// (E * Z)(w^i), zero where the sample is missing as Z vanishes there
+	ez := make([]ff.Fr, width, width)
+	for i, s := range samples {
+		if s != nil {
+			ff.FrMul(&ez[i], s, &zeroEvals[i])
+		}
+	}
+	ezPoly, err := fs.FFT(ez, true)
+	if err != nil {
+		return nil, fmt.Errorf("RecoverData: %w", err)
+	}
+
+	ezCoset, err := fs.CosetFFT(ezPoly, nil, false)
+	if err != nil {
+		return nil, fmt.Errorf("RecoverData: %w", err)
+	}
+	zeroCoset, err := fs.CosetFFT(zeroPoly, nil, false)
+	if err != nil {
+		return nil, fmt.Errorf("RecoverData: %w", err)
+	}
+	ff.BatchInvertParallel(zeroCoset, zeroCoset, fs.Concurrency)
+	for i := range ezCoset {
+		ff.FrMul(&ezCoset[i], &ezCoset[i], &zeroCoset[i])
+	}
+	poly, err := fs.CosetFFT(ezCoset, nil, true)
+	if err != nil {
+		return nil, fmt.Errorf("RecoverData: %w", err)
+	}
+
+	for i := width / 2; i < width; i++ {
+		if !poly[i].IsZero() {
+			return nil, fmt.Errorf("RecoverData: %w: the coefficient of x^%d is not zero", ErrNotCodeword, i)
+		}
+	}
+	out, err := fs.FFT(poly, false)
+	if err != nil {
+		return nil, fmt.Errorf("RecoverData: %w", err)
+	}
+	return out, nil
+}
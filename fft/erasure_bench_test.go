@@ -0,0 +1,35 @@
+package fft
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/accumulators-agg/go-poly/ff"
+)
+
+func BenchmarkFFTSettings_RecoverData(b *testing.B) {
+	for scale := uint8(4); scale < 13; scale++ {
+		b.Run(fmt.Sprintf("scale_%d", scale), func(b *testing.B) {
+			fs := NewFFTSettings(scale)
+			extended, err := fs.ExtendData(randomPoly(int(fs.MaxWidth / 2)))
+			if err != nil {
+				b.Fatal(err)
+			}
+			// Lose half of the samples
+			samples := make([]*ff.Fr, len(extended))
+			for i := range extended {
+				samples[i] = &extended[i]
+			}
+			for _, i := range rand.New(rand.NewSource(1)).Perm(len(samples))[:len(samples)/2] {
+				samples[i] = nil
+			}
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if _, err := fs.RecoverData(samples); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
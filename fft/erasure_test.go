@@ -0,0 +1,127 @@
+package fft
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/accumulators-agg/go-poly/ff"
+)
+
+func TestExtendData(t *testing.T) {
+	fs := NewFFTSettings(6)
+	for _, n := range []int{1, 2, 8, 32} {
+		t.Run(fmt.Sprintf("%d", n), func(t *testing.T) {
+			data := randomPoly(n)
+			extended, err := fs.ExtendData(data)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(extended) != 2*n {
+				t.Fatalf("ExtendData: expected %d values, got %d", 2*n, len(extended))
+			}
+			for i := range data {
+				if extended[2*i].IsEqual(&data[i]) == false {
+					t.Errorf("ExtendData: data[%d] is not at index %d", i, 2*i)
+				}
+			}
+			// All values lie on one polynomial of degree < n
+			coeffs, _ := fs.FFT(extended, true)
+			for i := n; i < 2*n; i++ {
+				if coeffs[i].IsZero() == false {
+					t.Errorf("ExtendData: the extension has degree %d", i)
+				}
+			}
+		})
+	}
+
+	if _, err := fs.ExtendData(randomPoly(3)); errors.Is(err, ErrNotPowerOfTwo) == false {
+		t.Errorf("ExtendData: expected %v, got %v", ErrNotPowerOfTwo, err)
+	}
+	if _, err := fs.ExtendData(randomPoly(64)); errors.Is(err, ErrDomainTooSmall) == false {
+		t.Errorf("ExtendData: expected %v, got %v", ErrDomainTooSmall, err)
+	}
+}
+
+// Returns the extension of random data with the given number of random samples erased
+func erasedSamples(t *testing.T, fs *FFTSettings, n int, erased int, rng *rand.Rand) ([]ff.Fr, []*ff.Fr) {
+	extended, err := fs.ExtendData(randomPoly(n))
+	if err != nil {
+		t.Fatal(err)
+	}
+	samples := make([]*ff.Fr, len(extended))
+	for i := range extended {
+		samples[i] = &extended[i]
+	}
+	for _, i := range rng.Perm(len(samples))[:erased] {
+		samples[i] = nil
+	}
+	return extended, samples
+}
+
+func TestRecoverData(t *testing.T) {
+	fs := NewFFTSettings(8)
+	rng := rand.New(rand.NewSource(1))
+	for _, n := range []int{1, 2, 16, 128} {
+		for _, erased := range []int{0, 1, n / 2, n - 1, n} {
+			testname := fmt.Sprintf("%d-%d", n, erased)
+			t.Run(testname, func(t *testing.T) {
+				for trial := 0; trial < 5; trial++ {
+					extended, samples := erasedSamples(t, fs, n, erased, rng)
+					recovered, err := fs.RecoverData(samples)
+					if err != nil {
+						t.Fatal(err)
+					}
+					if CheckEqualVec(recovered, extended) == false {
+						t.Fatalf("RecoverData: Answer did not match with the extended data.")
+					}
+				}
+			})
+		}
+	}
+
+	// Whole halves, the worst case for the division
+	extended, _ := fs.ExtendData(randomPoly(64))
+	for _, start := range []int{0, 64} {
+		samples := make([]*ff.Fr, 128)
+		for i := range samples {
+			if i < start || i >= start+64 {
+				samples[i] = &extended[i]
+			}
+		}
+		recovered, err := fs.RecoverData(samples)
+		if err != nil || CheckEqualVec(recovered, extended) == false {
+			t.Errorf("RecoverData: failed without the half starting at %d, %v", start, err)
+		}
+	}
+}
+
+func TestRecoverDataErrors(t *testing.T) {
+	fs := NewFFTSettings(6)
+	rng := rand.New(rand.NewSource(2))
+
+	_, samples := erasedSamples(t, fs, 16, 17, rng)
+	if _, err := fs.RecoverData(samples); errors.Is(err, ErrTooManyErasures) == false {
+		t.Errorf("RecoverData: expected %v, got %v", ErrTooManyErasures, err)
+	}
+
+	// With spare samples a corrupted one is detected
+	_, samples = erasedSamples(t, fs, 16, 10, rng)
+	for i := range samples {
+		if samples[i] != nil {
+			samples[i] = ff.RandomFr()
+			break
+		}
+	}
+	if _, err := fs.RecoverData(samples); errors.Is(err, ErrNotCodeword) == false {
+		t.Errorf("RecoverData: expected %v, got %v", ErrNotCodeword, err)
+	}
+
+	if _, err := fs.RecoverData(make([]*ff.Fr, 6)); errors.Is(err, ErrNotPowerOfTwo) == false {
+		t.Errorf("RecoverData: expected %v, got %v", ErrNotPowerOfTwo, err)
+	}
+	if _, err := fs.RecoverData(make([]*ff.Fr, 128)); errors.Is(err, ErrDomainTooSmall) == false {
+		t.Errorf("RecoverData: expected %v, got %v", ErrDomainTooSmall, err)
+	}
+}
@@ -0,0 +1,30 @@
+package fft
+
+import "errors"
+
+// Sentinel errors returned by the Try* polynomial routines, the FFTs and the decoders.
+// Returned errors wrap them with more context, match them with errors.Is.
+var (
+	// An input polynomial, point set or tree has no entries.
+	ErrEmptyInput = errors.New("empty input")
+	// The divisor is the zero polynomial.
+	ErrDivideByZero = errors.New("division by the zero polynomial")
+	// The input length must be a power of two.
+	ErrNotPowerOfTwo = errors.New("length is not a power of two")
+	// The degrees or sizes of the inputs do not fit together.
+	ErrDegreeMismatch = errors.New("degree mismatch")
+	// The FFTSettings do not have enough roots of unity for the input.
+	ErrDomainTooSmall = errors.New("not enough roots of unity")
+	// The coset shift is zero or lies in the subgroup of roots of unity.
+	ErrInvalidCosetShift = errors.New("invalid coset shift")
+	// More samples are missing than the code can recover.
+	ErrTooManyErasures = errors.New("too many erasures")
+	// The samples are not the evaluations of a polynomial of low enough degree.
+	ErrNotCodeword = errors.New("samples are not a codeword")
+	// The received values are too far from any codeword to be decoded.
+	ErrTooManyErrors = errors.New("too many errors")
+	// An encoded polynomial or tree is malformed, or was written under another curve.
+	ErrInvalidEncoding = errors.New("invalid encoding")
+	// An index does not point into the evaluation domain.
+	ErrIndexOutOfDomain = errors.New("index outside of the domain")
+)
@@ -0,0 +1,89 @@
+package fft
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/accumulators-agg/go-poly/ff"
+)
+
+func TestPolyErrors(t *testing.T) {
+	a := ff.FromInt64Vec([]int64{1, 2, 3, 4})
+	b := ff.FromInt64Vec([]int64{5, 1})
+	zero := ff.FromInt64Vec([]int64{0, 0})
+	empty := []ff.Fr{}
+	tree := SubProductTree(ff.FromInt64Vec([]int64{1, 2, 3, 4}))
+	small := NewFFTSettings(2)
+
+	var tests = []struct {
+		name string
+		run  func() error
+		want error
+	}{
+		{"IsPolyEqual-empty", func() error { _, err := TryIsPolyEqual(a, empty); return err }, ErrEmptyInput},
+		{"PolyCondense-empty", func() error { _, err := TryPolyCondense(empty); return err }, ErrEmptyInput},
+		{"PolyMul-empty", func() error { _, err := TryPolyMul(empty, b); return err }, ErrEmptyInput},
+		{"PolyMul-domain", func() error { _, err := small.TryPolyMul(a, a); return err }, ErrDomainTooSmall},
+		{"PolyLongDiv-zero", func() error { _, err := TryPolyLongDiv(a, zero); return err }, ErrDivideByZero},
+		{"PolyLongDiv-degree", func() error { _, err := TryPolyLongDiv(b, a); return err }, ErrDegreeMismatch},
+		{"PolyDiv-empty", func() error { _, _, err := TryPolyDiv(empty, b); return err }, ErrEmptyInput},
+		{"PolyDiv-zero", func() error { _, _, err := TryPolyDiv(a, zero); return err }, ErrDivideByZero},
+		{"PolyDiv-degree", func() error { _, _, err := TryPolyDiv(b, a); return err }, ErrDegreeMismatch},
+		{"PolyDiv-domain", func() error { _, _, err := small.TryPolyDiv(randomPoly(200), randomPoly(100)); return err }, ErrDomainTooSmall},
+		{"PolyDifferentiate-empty", func() error { _, err := TryPolyDifferentiate(empty); return err }, ErrEmptyInput},
+		{"SubProductTree-empty", func() error { _, err := TrySubProductTree(empty); return err }, ErrEmptyInput},
+		{"SubProductTree-pow2", func() error { _, err := TrySubProductTree(a[:3]); return err }, ErrNotPowerOfTwo},
+		{"SubProductTree-domain", func() error { _, err := small.TrySubProductTree(randomPoly(8)); return err }, ErrDomainTooSmall},
+		{"PolyMultiEvaluate-empty", func() error { _, err := TryPolyMultiEvaluate(empty, tree); return err }, ErrEmptyInput},
+		{"PolyMultiEvaluate-size", func() error { _, err := TryPolyMultiEvaluate(randomPoly(5), tree); return err }, ErrDegreeMismatch},
+		{"PolyMultiEvaluate-shape", func() error { _, err := TryPolyMultiEvaluate(a, tree[1:]); return err }, ErrDegreeMismatch},
+		{"PolyMultiEvaluate-zero", func() error {
+			bad := [][][]ff.Fr{{b, zero}, {b}}
+			_, err := TryPolyMultiEvaluate(b, bad)
+			return err
+		}, ErrDivideByZero},
+		{"FFT-pow2", func() error { return small.InplaceFFTDIT(randomPoly(3), false) }, ErrNotPowerOfTwo},
+		{"FFT-domain", func() error { _, err := small.FFT(randomPoly(8), false); return err }, ErrDomainTooSmall},
+		{"CosetFFT-zero", func() error { _, err := small.CosetFFT(a, &ff.ZERO, false); return err }, ErrInvalidCosetShift},
+		{"CosetFFT-subgroup", func() error { _, err := small.CosetFFT(a, &small.ExpandedRootsOfUnity[1], false); return err }, ErrInvalidCosetShift},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.run()
+			if errors.Is(err, tt.want) == false {
+				t.Errorf("expected %v, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestPolyTryMatchesPanicking(t *testing.T) {
+	a := randomPoly(150)
+	b := randomPoly(70)
+
+	c, err := TryPolyMul(a, b)
+	if err != nil || CheckEqualVec(c, PolyMul(a, b)) == false {
+		t.Errorf("TryPolyMul: Answer did not match with PolyMul.")
+	}
+	q, r, err := TryPolyDiv(a, b)
+	q2, r2 := PolyDiv(a, b)
+	if err != nil || CheckEqualVec(q, q2) == false || CheckEqualVec(r, r2) == false {
+		t.Errorf("TryPolyDiv: Answer did not match with PolyDiv.")
+	}
+	M, err := TrySubProductTree(a[:128])
+	if err != nil {
+		t.Fatalf("TrySubProductTree: unexpected error %v", err)
+	}
+	e, err := TryPolyMultiEvaluate(b, M)
+	if err != nil || CheckEqualVec(e, PolyMultiEvaluate(b, M)) == false {
+		t.Errorf("TryPolyMultiEvaluate: Answer did not match with PolyMultiEvaluate.")
+	}
+
+	defer func() {
+		if err, ok := recover().(error); !ok || errors.Is(err, ErrDivideByZero) == false {
+			t.Errorf("PolyDiv: expected a panic with ErrDivideByZero, got %v", err)
+		}
+	}()
+	PolyDiv(a, []ff.Fr{ff.ZERO})
+}
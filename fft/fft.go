@@ -3,10 +3,12 @@
 package fft
 
 import (
+	"fmt"
 	"math/bits"
+	"runtime"
+	"sync"
 
 	"github.com/accumulators-agg/go-poly/ff"
-	gmcl "github.com/alinush/go-mcl"
 )
 
 // if not already a power of 2, return the next power of 2
@@ -20,15 +22,15 @@ func nextPowOf2(v uint64) uint64 {
 // Expands the power circle for a given root of unity to WIDTH+1 values.
 // The first entry will be 1, the last entry will also be 1,
 // for convenience when reversing the array (useful for inverses)
-func expandRootOfUnity(RootOfUnity *gmcl.Fr) []gmcl.Fr {
-	rootz := make([]gmcl.Fr, 2)
+func expandRootOfUnity(RootOfUnity *ff.Fr) []ff.Fr {
+	rootz := make([]ff.Fr, 2)
 	rootz[0] = ff.ONE // some unused number in py code
 	rootz[1] = *RootOfUnity
 	for i := 1; !rootz[i].IsOne(); {
-		rootz = append(rootz, gmcl.Fr{})
+		rootz = append(rootz, ff.Fr{})
 		this := &rootz[i]
 		i++
-		gmcl.FrMul(&rootz[i], this, RootOfUnity)
+		ff.FrMul(&rootz[i], this, RootOfUnity)
 	}
 	return rootz
 }
@@ -36,19 +38,35 @@ func expandRootOfUnity(RootOfUnity *gmcl.Fr) []gmcl.Fr {
 type FFTSettings struct {
 	MaxWidth uint64
 	// the generator used to get all roots of unity
-	RootOfUnity *gmcl.Fr
+	RootOfUnity *ff.Fr
 	// domain, starting and ending with 1 (duplicate!)
-	ExpandedRootsOfUnity []gmcl.Fr
+	ExpandedRootsOfUnity []ff.Fr
 	// reverse domain, same as inverse values of domain. Also starting and ending with 1.
-	ReverseRootsOfUnity []gmcl.Fr
+	ReverseRootsOfUnity []ff.Fr
+	// max number of goroutines a single transform may use, 1 or less runs on the calling goroutine.
+	// Results do not depend on it.
+	Concurrency int
 }
 
+// Panics if the selected curve has no roots of unity of order 2^maxScale, see TryNewFFTSettings.
 func NewFFTSettings(maxScale uint8) *FFTSettings {
+	fs, err := TryNewFFTSettings(maxScale)
+	if err != nil {
+		panic(err)
+	}
+	return fs
+}
+
+// Same as NewFFTSettings, but returns ErrDomainTooSmall if maxScale is above ff.TwoAdicity().
+func TryNewFFTSettings(maxScale uint8) (*FFTSettings, error) {
+	if maxScale > ff.TwoAdicity() {
+		return nil, fmt.Errorf("%w: scale %d is above the 2-adicity %d of %s", ErrDomainTooSmall, maxScale, ff.TwoAdicity(), ff.CurrentCurve())
+	}
 	width := uint64(1) << maxScale
 	root := &ff.Scale2RootOfUnity[maxScale]
 	rootz := expandRootOfUnity(&ff.Scale2RootOfUnity[maxScale])
 	// reverse roots of unity
-	rootzReverse := make([]gmcl.Fr, len(rootz), len(rootz))
+	rootzReverse := make([]ff.Fr, len(rootz), len(rootz))
 	copy(rootzReverse, rootz)
 	for i, j := uint64(0), uint64(len(rootz)-1); i < j; i, j = i+1, j-1 {
 		rootzReverse[i], rootzReverse[j] = rootzReverse[j], rootzReverse[i]
@@ -59,5 +77,75 @@ func NewFFTSettings(maxScale uint8) *FFTSettings {
 		RootOfUnity:          root,
 		ExpandedRootsOfUnity: rootz,
 		ReverseRootsOfUnity:  rootzReverse,
+		Concurrency:          runtime.GOMAXPROCS(0),
+	}, nil
+}
+
+var (
+	settingsCacheLock sync.RWMutex
+	settingsCache     = make(map[settingsKey]*FFTSettings)
+)
+
+// Settings depend on the roots of unity, so they are cached per curve.
+type settingsKey struct {
+	curve ff.Curve
+	scale uint8
+}
+
+// Methods called on the nil settings take them from the process-wide cache,
+// sized to each transform. The package level poly functions go through it.
+var cachedSettings *FFTSettings
+
+// Returns the process-wide FFTSettings of the given scale, building them on first use.
+// Safe for concurrent use. The returned settings are shared and must not be modified.
+// Panics like NewFFTSettings if maxScale is above ff.TwoAdicity().
+func GetFFTSettings(maxScale uint8) *FFTSettings {
+	fs, err := getFFTSettings(maxScale)
+	if err != nil {
+		panic(err)
+	}
+	return fs
+}
+
+func getFFTSettings(maxScale uint8) (*FFTSettings, error) {
+	key := settingsKey{ff.CurrentCurve(), maxScale}
+	settingsCacheLock.RLock()
+	fs, ok := settingsCache[key]
+	settingsCacheLock.RUnlock()
+	if ok {
+		return fs, nil
+	}
+
+	settingsCacheLock.Lock()
+	defer settingsCacheLock.Unlock()
+	if fs, ok = settingsCache[key]; !ok {
+		var err error
+		if fs, err = TryNewFFTSettings(maxScale); err != nil {
+			return nil, err
+		}
+		settingsCache[key] = fs
+	}
+	return fs, nil
+}
+
+// Returns settings able to run a transform of n values (a power of two).
+// The nil settings resolve to the cached settings of exactly that width,
+// explicit settings are used as they are and must be wide enough.
+func (fs *FFTSettings) forWidth(n uint64) (*FFTSettings, error) {
+	if fs == nil {
+		return getFFTSettings(uint8(bits.Len64(nextPowOf2(n))) - 1)
+	}
+	if n > fs.MaxWidth {
+		return nil, fmt.Errorf("%w: need %d but only have %d", ErrDomainTooSmall, n, fs.MaxWidth)
+	}
+	return fs, nil
+}
+
+// Checks that fs can multiply polynomials of up to n coefficients each.
+func (fs *FFTSettings) checkMulWidth(n int) error {
+	if n <= 1 {
+		return nil
 	}
+	_, err := fs.forWidth(nextPowOf2(uint64(2 * n)))
+	return err
 }
@@ -0,0 +1,88 @@
+package fft
+
+import (
+	"fmt"
+
+	"github.com/accumulators-agg/go-poly/ff"
+)
+
+// Returns the default coset shift if shift is nil, and checks that shift * H is a proper coset
+// of the subgroup H of the n-th roots of unity, i.e. shift^n != 1.
+func cosetShift(shift *ff.Fr, n uint64) (*ff.Fr, error) {
+	if shift == nil {
+		return &ff.PRIMITIVE_ROOT, nil
+	}
+	if shift.IsZero() {
+		return nil, fmt.Errorf("%w: must be non-zero", ErrInvalidCosetShift)
+	}
+	var pow ff.Fr
+	ff.CopyFr(&pow, shift)
+	for i := uint64(1); i < n; i <<= 1 {
+		ff.FrMul(&pow, &pow, &pow)
+	}
+	if pow.IsOne() {
+		return nil, fmt.Errorf("%w: %s is in the subgroup of %d roots of unity", ErrInvalidCosetShift, ff.FrStr(shift), n)
+	}
+	return shift, nil
+}
+
+// Multiplies vals[i] by factor^i
+func scalePowers(vals []ff.Fr, factor *ff.Fr) {
+	var pow ff.Fr
+	ff.CopyFr(&pow, &ff.ONE)
+	for i := 0; i < len(vals); i++ {
+		ff.FrMul(&vals[i], &vals[i], &pow)
+		ff.FrMul(&pow, &pow, factor)
+	}
+}
+
+// Coset FFT: evaluates the polynomial with coefficients vals over shift * H,
+// where H is the subgroup of len(vals) roots of unity (padded to a power of two).
+// With inv, interpolates the values over shift * H back to coefficients.
+// A nil shift uses ff.PRIMITIVE_ROOT.
+func (fs *FFTSettings) CosetFFT(vals []ff.Fr, shift *ff.Fr, inv bool) ([]ff.Fr, error) {
+	n := nextPowOf2(uint64(len(vals)))
+	fs, err := fs.forWidth(n)
+	if err != nil {
+		return nil, err
+	}
+	// We make a copy so we can mutate it during the work.
+	valsCopy := make([]ff.Fr, n, n)
+	for i := 0; i < len(vals); i++ {
+		ff.CopyFr(&valsCopy[i], &vals[i])
+	}
+	for i := uint64(len(vals)); i < n; i++ {
+		ff.CopyFr(&valsCopy[i], &ff.ZERO)
+	}
+	out := make([]ff.Fr, n, n)
+	if err := fs.InplaceCosetFFT(valsCopy, out, shift, inv); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Same as CosetFFT, writing the result to out.
+// Note: vals is used as scratch space and is modified for the forward transform.
+func (fs *FFTSettings) InplaceCosetFFT(vals []ff.Fr, out []ff.Fr, shift *ff.Fr, inv bool) error {
+	n := uint64(len(vals))
+	fs, err := fs.forTransform(n)
+	if err != nil {
+		return err
+	}
+	shift, err = cosetShift(shift, n)
+	if err != nil {
+		return err
+	}
+	if inv {
+		if err := fs.InplaceFFT(vals, out, true); err != nil {
+			return err
+		}
+		var invShift ff.Fr
+		ff.FrInv(&invShift, shift)
+		scalePowers(out, &invShift)
+		return nil
+	} else {
+		scalePowers(vals, shift)
+		return fs.InplaceFFT(vals, out, false)
+	}
+}
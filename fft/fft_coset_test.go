@@ -0,0 +1,55 @@
+package fft
+
+import (
+	"testing"
+
+	"github.com/accumulators-agg/go-poly/ff"
+)
+
+func TestCosetFFT(t *testing.T) {
+	fs := NewFFTSettings(4)
+	data := make([]ff.Fr, fs.MaxWidth, fs.MaxWidth)
+	for i := uint64(0); i < fs.MaxWidth; i++ {
+		ff.AsFr(&data[i], i)
+	}
+
+	var shift ff.Fr
+	ff.AsFr(&shift, 7)
+	for _, s := range []*ff.Fr{nil, &shift} {
+		evals, err := fs.CosetFFT(data, s, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if s == nil {
+			s = &ff.PRIMITIVE_ROOT
+		}
+		var x, expected ff.Fr
+		for i := range evals {
+			ff.FrMul(&x, s, &fs.ExpandedRootsOfUnity[i])
+			expected = PolyEval(data, &x)
+			if got := &evals[i]; !got.IsEqual(&expected) {
+				t.Errorf("difference: %d: got: %s  expected: %s", i, ff.FrStr(got), ff.FrStr(&expected))
+			}
+		}
+
+		res, err := fs.CosetFFT(evals, s, true)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i := range res {
+			if got, expected := &res[i], &data[i]; !got.IsEqual(expected) {
+				t.Errorf("difference: %d: got: %s  expected: %s", i, ff.FrStr(got), ff.FrStr(expected))
+			}
+		}
+	}
+}
+
+func TestCosetFFTInvalidShift(t *testing.T) {
+	fs := NewFFTSettings(4)
+	data := make([]ff.Fr, fs.MaxWidth, fs.MaxWidth)
+	for _, s := range []*ff.Fr{&ff.ZERO, &ff.ONE, &fs.ExpandedRootsOfUnity[3]} {
+		if _, err := fs.CosetFFT(data, s, false); err == nil {
+			t.Errorf("expected an error for shift %s", ff.FrStr(s))
+		}
+	}
+}
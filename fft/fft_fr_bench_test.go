@@ -2,15 +2,25 @@ package fft
 
 import (
 	"fmt"
+	"runtime"
 	"testing"
 
 	"github.com/accumulators-agg/go-poly/ff"
-	gmcl "github.com/alinush/go-mcl"
 )
 
 func benchFFT(scale uint8, inv bool, b *testing.B) {
 	fs := NewFFTSettings(scale)
-	data := make([]gmcl.Fr, fs.MaxWidth, fs.MaxWidth)
+	benchFFTSettings(fs, inv, b)
+}
+
+func benchFFTConcurrency(scale uint8, concurrency int, b *testing.B) {
+	fs := NewFFTSettings(scale)
+	fs.Concurrency = concurrency
+	benchFFTSettings(fs, false, b)
+}
+
+func benchFFTSettings(fs *FFTSettings, inv bool, b *testing.B) {
+	data := make([]ff.Fr, fs.MaxWidth, fs.MaxWidth)
 	for i := uint64(0); i < fs.MaxWidth; i++ {
 		ff.CopyFr(&data[i], ff.RandomFr())
 	}
@@ -36,6 +46,22 @@ func BenchmarkFFTSettings_FFT(b *testing.B) {
 	}
 }
 
+func BenchmarkFFTSettings_FFTSequential(b *testing.B) {
+	for scale := uint8(4); scale < 17; scale++ {
+		b.Run(fmt.Sprintf("scale_%d", scale), func(b *testing.B) {
+			benchFFTConcurrency(scale, 1, b)
+		})
+	}
+}
+
+func BenchmarkFFTSettings_FFTParallel(b *testing.B) {
+	for scale := uint8(4); scale < 17; scale++ {
+		b.Run(fmt.Sprintf("scale_%d", scale), func(b *testing.B) {
+			benchFFTConcurrency(scale, runtime.GOMAXPROCS(0), b)
+		})
+	}
+}
+
 func BenchmarkFFTSettings_InvFFT(b *testing.B) {
 	for scale := uint8(4); scale < 17; scale++ {
 		b.Run(fmt.Sprintf("scale_%d", scale), func(b *testing.B) {
@@ -43,3 +69,41 @@ func BenchmarkFFTSettings_InvFFT(b *testing.B) {
 		})
 	}
 }
+
+func benchInplaceFFT(scale uint8, fn func(fs *FFTSettings, vals []ff.Fr) error, b *testing.B) {
+	fs := NewFFTSettings(scale)
+	data := make([]ff.Fr, fs.MaxWidth, fs.MaxWidth)
+	for i := uint64(0); i < fs.MaxWidth; i++ {
+		ff.CopyFr(&data[i], ff.RandomFr())
+	}
+	work := make([]ff.Fr, fs.MaxWidth, fs.MaxWidth)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		copy(work, data)
+		b.StartTimer()
+		if err := fn(fs, work); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkFFTSettings_InplaceFFTDIT(b *testing.B) {
+	for scale := uint8(4); scale < 17; scale++ {
+		b.Run(fmt.Sprintf("scale_%d", scale), func(b *testing.B) {
+			benchInplaceFFT(scale, func(fs *FFTSettings, vals []ff.Fr) error {
+				return fs.InplaceFFTDIT(vals, false)
+			}, b)
+		})
+	}
+}
+
+func BenchmarkFFTSettings_InplaceFFTDIFNoPermute(b *testing.B) {
+	for scale := uint8(4); scale < 17; scale++ {
+		b.Run(fmt.Sprintf("scale_%d", scale), func(b *testing.B) {
+			benchInplaceFFT(scale, func(fs *FFTSettings, vals []ff.Fr) error {
+				return fs.InplaceFFTDIFNoPermute(vals, false)
+			}, b)
+		})
+	}
+}
@@ -5,12 +5,11 @@ import (
 
 	"github.com/accumulators-agg/go-poly/debug"
 	"github.com/accumulators-agg/go-poly/ff"
-	gmcl "github.com/alinush/go-mcl"
 )
 
 func TestFFTRoundtrip(t *testing.T) {
 	fs := NewFFTSettings(4)
-	data := make([]gmcl.Fr, fs.MaxWidth, fs.MaxWidth)
+	data := make([]ff.Fr, fs.MaxWidth, fs.MaxWidth)
 	for i := uint64(0); i < fs.MaxWidth; i++ {
 		ff.AsFr(&data[i], i)
 	}
@@ -33,7 +32,7 @@ func TestFFTRoundtrip(t *testing.T) {
 
 func TestInvFFT(t *testing.T) {
 	fs := NewFFTSettings(4)
-	data := make([]gmcl.Fr, fs.MaxWidth, fs.MaxWidth)
+	data := make([]ff.Fr, fs.MaxWidth, fs.MaxWidth)
 	for i := uint64(0); i < fs.MaxWidth; i++ {
 		ff.AsFr(&data[i], i)
 	}
@@ -43,11 +42,11 @@ func TestInvFFT(t *testing.T) {
 		t.Fatal(err)
 	}
 	debug.DebugFrs("result", res)
-	ToFr := func(v string) (out gmcl.Fr) {
+	ToFr := func(v string) (out ff.Fr) {
 		ff.SetFr(&out, v)
 		return
 	}
-	expected := []gmcl.Fr{
+	expected := []ff.Fr{
 		ToFr("26217937587563095239723870254092982918845276250263818911301829349969290592264"),
 		ToFr("40905488090558605688319636812215252217941835718478251840326926365086504505065"),
 		ToFr("10037948829646534413413739647971946522809495755620173630072972432081702959148"),
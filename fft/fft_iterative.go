@@ -0,0 +1,149 @@
+package fft
+
+import (
+	"fmt"
+	"math/bits"
+
+	"github.com/accumulators-agg/go-poly/ff"
+)
+
+// Permutes vals in place, swapping every index with its bit-reversed index.
+// len(vals) must be a power of two.
+func BitReversePermutation(vals []ff.Fr) {
+	n := uint64(len(vals))
+	if n <= 1 {
+		return
+	}
+	shift := 64 - uint(bits.Len64(n-1))
+	for i := uint64(0); i < n; i++ {
+		j := bits.Reverse64(i) >> shift
+		if i < j {
+			vals[i], vals[j] = vals[j], vals[i]
+		}
+	}
+}
+
+// Returns settings able to transform n values in place, like forWidth.
+func (fs *FFTSettings) forTransform(n uint64) (*FFTSettings, error) {
+	if !ff.IsPowerOfTwo(n) {
+		return nil, fmt.Errorf("%w: got %d values", ErrNotPowerOfTwo, n)
+	}
+	return fs.forWidth(n)
+}
+
+// Returns the roots of unity and the stride to use for a transform of size n.
+func (fs *FFTSettings) rootsFor(n uint64, inv bool) ([]ff.Fr, uint64) {
+	if inv {
+		return fs.ReverseRootsOfUnity[:fs.MaxWidth], fs.MaxWidth / n
+	}
+	return fs.ExpandedRootsOfUnity[:fs.MaxWidth], fs.MaxWidth / n
+}
+
+// Multiplies every value by 1/n, for inverse transforms.
+func scaleInvLen(vals []ff.Fr, workers int) {
+	var invLen ff.Fr
+	ff.AsFr(&invLen, uint64(len(vals)))
+	ff.FrInv(&invLen, &invLen)
+	parallelRange(uint64(len(vals)), workers, func(lo, hi uint64) {
+		for i := lo; i < hi; i++ {
+			ff.FrMul(&vals[i], &vals[i], &invLen)
+		}
+	})
+}
+
+// Decimation-in-frequency (Gentleman–Sande) butterflies.
+// Input in natural order, output in bit-reversed order.
+// The n/2 butterflies of every layer are split across up to workers goroutines.
+func difKernel(vals []ff.Fr, rootsOfUnity []ff.Fr, rootsOfUnityStride uint64, workers int) {
+	n := uint64(len(vals))
+	for half := n >> 1; half >= 1; half >>= 1 {
+		stride := rootsOfUnityStride * (n / (half << 1))
+		parallelRange(n>>1, workers, func(lo, hi uint64) {
+			var x, y ff.Fr
+			for k := lo; k < hi; k++ {
+				// j-th butterfly of its group
+				j := k & (half - 1)
+				i := ((k - j) << 1) + j
+				ff.CopyFr(&x, &vals[i])
+				ff.CopyFr(&y, &vals[i+half])
+				ff.FrAdd(&vals[i], &x, &y)
+				ff.FrSub(&y, &x, &y)
+				ff.FrMul(&vals[i+half], &y, &rootsOfUnity[j*stride])
+			}
+		})
+	}
+}
+
+// Decimation-in-time (Cooley–Tukey) butterflies.
+// Input in bit-reversed order, output in natural order.
+// The n/2 butterflies of every layer are split across up to workers goroutines.
+func ditKernel(vals []ff.Fr, rootsOfUnity []ff.Fr, rootsOfUnityStride uint64, workers int) {
+	n := uint64(len(vals))
+	for half := uint64(1); half < n; half <<= 1 {
+		stride := rootsOfUnityStride * (n / (half << 1))
+		parallelRange(n>>1, workers, func(lo, hi uint64) {
+			var x, yTimesRoot ff.Fr
+			for k := lo; k < hi; k++ {
+				// j-th butterfly of its group
+				j := k & (half - 1)
+				i := ((k - j) << 1) + j
+				ff.CopyFr(&x, &vals[i])
+				ff.FrMul(&yTimesRoot, &vals[i+half], &rootsOfUnity[j*stride])
+				ff.FrAdd(&vals[i], &x, &yTimesRoot)
+				ff.FrSub(&vals[i+half], &x, &yTimesRoot)
+			}
+		})
+	}
+}
+
+// Iterative in-place FFT, decimation-in-time. Natural order in and out.
+func (fs *FFTSettings) InplaceFFTDIT(vals []ff.Fr, inv bool) error {
+	fs, err := fs.forTransform(uint64(len(vals)))
+	if err != nil {
+		return err
+	}
+	BitReversePermutation(vals)
+	return fs.InplaceFFTDITNoPermute(vals, inv)
+}
+
+// Iterative in-place FFT, decimation-in-frequency. Natural order in and out.
+func (fs *FFTSettings) InplaceFFTDIF(vals []ff.Fr, inv bool) error {
+	if err := fs.InplaceFFTDIFNoPermute(vals, inv); err != nil {
+		return err
+	}
+	BitReversePermutation(vals)
+	return nil
+}
+
+// Iterative in-place FFT, decimation-in-time, without the bit-reversal permutation.
+// Input in bit-reversed order, output in natural order.
+func (fs *FFTSettings) InplaceFFTDITNoPermute(vals []ff.Fr, inv bool) error {
+	n := uint64(len(vals))
+	fs, err := fs.forTransform(n)
+	if err != nil {
+		return err
+	}
+	rootz, stride := fs.rootsFor(n, inv)
+	ditKernel(vals, rootz, stride, fs.Concurrency)
+	if inv {
+		scaleInvLen(vals, fs.Concurrency)
+	}
+	return nil
+}
+
+// Iterative in-place FFT, decimation-in-frequency, without the bit-reversal permutation.
+// Input in natural order, output in bit-reversed order.
+// Chain with InplaceFFTDITNoPermute to get back to natural order without ever reordering.
+func (fs *FFTSettings) InplaceFFTDIFNoPermute(vals []ff.Fr, inv bool) error {
+	n := uint64(len(vals))
+	fs, err := fs.forTransform(n)
+	if err != nil {
+		return err
+	}
+	rootz, stride := fs.rootsFor(n, inv)
+	difKernel(vals, rootz, stride, fs.Concurrency)
+	if inv {
+		scaleInvLen(vals, fs.Concurrency)
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package fft
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/accumulators-agg/go-poly/ff"
+)
+
+func TestBitReversePermutation(t *testing.T) {
+	data := ff.FromInt64Vec([]int64{0, 1, 2, 3, 4, 5, 6, 7})
+	want := ff.FromInt64Vec([]int64{0, 4, 2, 6, 1, 5, 3, 7})
+	BitReversePermutation(data)
+	if CheckEqualVec(data, want) == false {
+		t.Errorf("BitReversePermutation: Answer did not match with expected.")
+	}
+}
+
+func TestInplaceFFTIterative(t *testing.T) {
+	fs := NewFFTSettings(6)
+	for scale := uint8(0); scale <= 6; scale++ {
+		for _, inv := range []bool{false, true} {
+			testname := fmt.Sprintf("scale-%d-inv-%v", scale, inv)
+			t.Run(testname, func(t *testing.T) {
+				n := uint64(1) << scale
+				data := make([]ff.Fr, n, n)
+				for i := uint64(0); i < n; i++ {
+					data[i] = *ff.RandomFr()
+				}
+				expected, err := fs.FFT(data, inv)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				dit := make([]ff.Fr, n, n)
+				copy(dit, data)
+				if err := fs.InplaceFFTDIT(dit, inv); err != nil {
+					t.Fatal(err)
+				}
+				if CheckEqualVec(dit, expected) == false {
+					t.Errorf("InplaceFFTDIT: Answer did not match with FFT.")
+				}
+
+				dif := make([]ff.Fr, n, n)
+				copy(dif, data)
+				if err := fs.InplaceFFTDIF(dif, inv); err != nil {
+					t.Fatal(err)
+				}
+				if CheckEqualVec(dif, expected) == false {
+					t.Errorf("InplaceFFTDIF: Answer did not match with FFT.")
+				}
+
+				// DIF without permutation, then DIT inverse without permutation, is the identity.
+				roundtrip := make([]ff.Fr, n, n)
+				copy(roundtrip, data)
+				if err := fs.InplaceFFTDIFNoPermute(roundtrip, inv); err != nil {
+					t.Fatal(err)
+				}
+				BitReversePermutation(roundtrip)
+				if CheckEqualVec(roundtrip, expected) == false {
+					t.Errorf("InplaceFFTDIFNoPermute: Answer did not match with bit-reversed FFT.")
+				}
+				BitReversePermutation(roundtrip)
+				if err := fs.InplaceFFTDITNoPermute(roundtrip, !inv); err != nil {
+					t.Fatal(err)
+				}
+				if CheckEqualVec(roundtrip, data) == false {
+					t.Errorf("InplaceFFTDITNoPermute: Roundtrip did not match with input.")
+				}
+			})
+		}
+	}
+}
+
+func TestInplaceFFTIterativeInvalidWidth(t *testing.T) {
+	fs := NewFFTSettings(3)
+	if err := fs.InplaceFFTDIT(make([]ff.Fr, 6), false); err == nil {
+		t.Errorf("expected an error for a non power of two width")
+	}
+	if err := fs.InplaceFFTDIF(make([]ff.Fr, 16), false); err == nil {
+		t.Errorf("expected an error for a width above MaxWidth")
+	}
+}
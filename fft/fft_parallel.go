@@ -0,0 +1,78 @@
+package fft
+
+import (
+	"sync"
+
+	"github.com/accumulators-agg/go-poly/ff"
+)
+
+// Transforms (and butterfly layers) smaller than this always run on a single goroutine.
+// TODO: tune threshold.
+const parallelFFTThreshold = 1 << 10
+
+// Splits [0, n) into at most workers contiguous chunks and runs fn on each of them concurrently.
+// Small ranges, or a single worker, run fn(0, n) on the calling goroutine.
+func parallelRange(n uint64, workers int, fn func(lo, hi uint64)) {
+	if n < parallelFFTThreshold {
+		fn(0, n)
+		return
+	}
+	parallelChunks(n, workers, fn)
+}
+
+// Same as parallelRange, without the size threshold, for ranges whose items are expensive on their own.
+func parallelChunks(n uint64, workers int, fn func(lo, hi uint64)) {
+	if workers <= 1 || n <= 1 {
+		fn(0, n)
+		return
+	}
+	chunk := (n + uint64(workers) - 1) / uint64(workers)
+	var wg sync.WaitGroup
+	for lo := uint64(0); lo < n; lo += chunk {
+		hi := lo + chunk
+		if hi > n {
+			hi = n
+		}
+		wg.Add(1)
+		go func(lo, hi uint64) {
+			defer wg.Done()
+			fn(lo, hi)
+		}(lo, hi)
+	}
+	wg.Wait()
+}
+
+// Same as _fft, but runs the two recursive halves, and the butterflies that combine them,
+// on up to workers goroutines.
+func (fs *FFTSettings) _fftParallel(vals []ff.Fr, valsOffset uint64, valsStride uint64, rootsOfUnity []ff.Fr, rootsOfUnityStride uint64, out []ff.Fr, workers int) {
+	if workers <= 1 || len(out) < parallelFFTThreshold {
+		fs._fft(vals, valsOffset, valsStride, rootsOfUnity, rootsOfUnityStride, out)
+		return
+	}
+
+	half := uint64(len(out)) >> 1
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		// L will be the left half of out
+		fs._fftParallel(vals, valsOffset, valsStride<<1, rootsOfUnity, rootsOfUnityStride<<1, out[:half], workers/2)
+	}()
+	// R will be the right half of out
+	fs._fftParallel(vals, valsOffset+valsStride, valsStride<<1, rootsOfUnity, rootsOfUnityStride<<1, out[half:], workers-workers/2)
+	wg.Wait()
+
+	parallelRange(half, workers, func(lo, hi uint64) {
+		var yTimesRoot ff.Fr
+		var x, y ff.Fr
+		for i := lo; i < hi; i++ {
+			// temporary copies, so that writing to output doesn't conflict with input
+			ff.CopyFr(&x, &out[i])
+			ff.CopyFr(&y, &out[i+half])
+			root := &rootsOfUnity[i*rootsOfUnityStride]
+			ff.FrMul(&yTimesRoot, &y, root)
+			ff.FrAdd(&out[i], &x, &yTimesRoot)
+			ff.FrSub(&out[i+half], &x, &yTimesRoot)
+		}
+	})
+}
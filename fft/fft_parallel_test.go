@@ -0,0 +1,66 @@
+package fft
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/accumulators-agg/go-poly/ff"
+)
+
+func TestParallelFFT(t *testing.T) {
+	fs := NewFFTSettings(11)
+	data := make([]ff.Fr, fs.MaxWidth, fs.MaxWidth)
+	for i := uint64(0); i < fs.MaxWidth; i++ {
+		ff.CopyFr(&data[i], ff.RandomFr())
+	}
+
+	fs.Concurrency = 1
+	expected, err := fs.FFT(data, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedInv, err := fs.FFT(data, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, concurrency := range []int{2, 3, 8} {
+		t.Run(fmt.Sprintf("concurrency-%d", concurrency), func(t *testing.T) {
+			fs.Concurrency = concurrency
+			defer func() { fs.Concurrency = 1 }()
+
+			res, err := fs.FFT(data, false)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if CheckEqualVec(res, expected) == false {
+				t.Errorf("FFT: Parallel answer did not match with sequential.")
+			}
+			res, err = fs.FFT(data, true)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if CheckEqualVec(res, expectedInv) == false {
+				t.Errorf("FFT: Parallel inverse answer did not match with sequential.")
+			}
+
+			dit := make([]ff.Fr, fs.MaxWidth, fs.MaxWidth)
+			copy(dit, data)
+			if err := fs.InplaceFFTDIT(dit, false); err != nil {
+				t.Fatal(err)
+			}
+			if CheckEqualVec(dit, expected) == false {
+				t.Errorf("InplaceFFTDIT: Parallel answer did not match with sequential.")
+			}
+
+			dif := make([]ff.Fr, fs.MaxWidth, fs.MaxWidth)
+			copy(dif, data)
+			if err := fs.InplaceFFTDIF(dif, true); err != nil {
+				t.Fatal(err)
+			}
+			if CheckEqualVec(dif, expectedInv) == false {
+				t.Errorf("InplaceFFTDIF: Parallel inverse answer did not match with sequential.")
+			}
+		})
+	}
+}
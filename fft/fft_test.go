@@ -0,0 +1,254 @@
+package fft
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/accumulators-agg/go-poly/ff"
+)
+
+func TestGetFFTSettings(t *testing.T) {
+	fs := GetFFTSettings(5)
+	if fs.MaxWidth != 32 {
+		t.Fatalf("GetFFTSettings: expected width 32, got %d", fs.MaxWidth)
+	}
+	if GetFFTSettings(5) != fs {
+		t.Errorf("GetFFTSettings: expected the cached settings to be reused")
+	}
+
+	// Concurrent first use of a scale must build it only once.
+	const workers = 16
+	got := make([]*FFTSettings, workers)
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			got[i] = GetFFTSettings(9)
+		}(i)
+	}
+	wg.Wait()
+	for i := 1; i < workers; i++ {
+		if got[i] != got[0] {
+			t.Fatalf("GetFFTSettings: concurrent callers got different settings")
+		}
+	}
+}
+
+func TestPolyMulExplicitSettings(t *testing.T) {
+	fs := NewFFTSettings(8)
+	for _, sizes := range [][2]int{{1, 1}, {3, 5}, {16, 16}, {64, 40}} {
+		testname := fmt.Sprintf("%d-%d", sizes[0], sizes[1])
+		t.Run(testname, func(t *testing.T) {
+			a := randomPoly(sizes[0])
+			b := randomPoly(sizes[1])
+			if CheckEqualVec(fs.PolyMul(a, b), PolyMul(a, b)) == false {
+				t.Errorf("PolyMul: Answer with explicit settings did not match with the cached one.")
+			}
+		})
+	}
+
+	t.Run("too-small", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Errorf("PolyMul: expected a panic when the settings are too small")
+			}
+		}()
+		fs.PolyMul(randomPoly(200), randomPoly(200))
+	})
+}
+
+func TestNilSettings(t *testing.T) {
+	var nilFs *FFTSettings
+	fs := NewFFTSettings(8)
+	data := randomPoly(16)
+
+	transforms := map[string]func(fs *FFTSettings) ([]ff.Fr, error){
+		"TryPolyMul": func(fs *FFTSettings) ([]ff.Fr, error) {
+			return fs.TryPolyMul(data, data[:5])
+		},
+		"TryPolyDiv": func(fs *FFTSettings) ([]ff.Fr, error) {
+			q, _, err := fs.TryPolyDiv(data, data[:5])
+			return q, err
+		},
+		"TryPolyMultiEvaluate": func(fs *FFTSettings) ([]ff.Fr, error) {
+			M, err := fs.TrySubProductTree(data[:8])
+			if err != nil {
+				return nil, err
+			}
+			return fs.TryPolyMultiEvaluate(data[:5], M)
+		},
+		"FFT": func(fs *FFTSettings) ([]ff.Fr, error) {
+			return fs.FFT(data, false)
+		},
+		"InplaceFFT": func(fs *FFTSettings) ([]ff.Fr, error) {
+			out := make([]ff.Fr, len(data), len(data))
+			return out, fs.InplaceFFT(data, out, true)
+		},
+		"CosetFFT": func(fs *FFTSettings) ([]ff.Fr, error) {
+			return fs.CosetFFT(data, nil, false)
+		},
+		"InplaceCosetFFT": func(fs *FFTSettings) ([]ff.Fr, error) {
+			vals := append([]ff.Fr(nil), data...)
+			out := make([]ff.Fr, len(data), len(data))
+			return out, fs.InplaceCosetFFT(vals, out, nil, false)
+		},
+		"InplaceFFTDIT": func(fs *FFTSettings) ([]ff.Fr, error) {
+			vals := append([]ff.Fr(nil), data...)
+			return vals, fs.InplaceFFTDIT(vals, false)
+		},
+		"InplaceFFTDIF": func(fs *FFTSettings) ([]ff.Fr, error) {
+			vals := append([]ff.Fr(nil), data...)
+			return vals, fs.InplaceFFTDIF(vals, true)
+		},
+		"InplaceFFTDITNoPermute": func(fs *FFTSettings) ([]ff.Fr, error) {
+			vals := append([]ff.Fr(nil), data...)
+			return vals, fs.InplaceFFTDITNoPermute(vals, false)
+		},
+		"InplaceFFTDIFNoPermute": func(fs *FFTSettings) ([]ff.Fr, error) {
+			vals := append([]ff.Fr(nil), data...)
+			return vals, fs.InplaceFFTDIFNoPermute(vals, false)
+		},
+		"ExtendData": func(fs *FFTSettings) ([]ff.Fr, error) {
+			return fs.ExtendData(data)
+		},
+		"RecoverData": func(fs *FFTSettings) ([]ff.Fr, error) {
+			extended, err := fs.ExtendData(data)
+			if err != nil {
+				return nil, err
+			}
+			samples := make([]*ff.Fr, len(extended), len(extended))
+			for i := 0; i < len(samples); i += 2 {
+				samples[i] = &extended[i]
+			}
+			return fs.RecoverData(samples)
+		},
+		"ZeroPolyViaMultiplication": func(fs *FFTSettings) ([]ff.Fr, error) {
+			coeffs, _, err := fs.ZeroPolyViaMultiplication([]uint64{1, 5, 6, 11}, 16)
+			return coeffs, err
+		},
+		"DecodeData": func(fs *FFTSettings) ([]ff.Fr, error) {
+			extended, err := fs.ExtendData(data)
+			if err != nil {
+				return nil, err
+			}
+			extended[3] = ff.ONE
+			f, _, err := fs.DecodeData(extended, len(data))
+			return f, err
+		},
+		"DecodeRS": func(fs *FFTSettings) ([]ff.Fr, error) {
+			ys := fs.PolyEvalMany(data[:4], data)
+			ys[5] = ff.ONE
+			f, _, err := fs.DecodeRS(data, ys, 4)
+			return f, err
+		},
+	}
+	for name, transform := range transforms {
+		t.Run(name, func(t *testing.T) {
+			expected, err := transform(fs)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := transform(nilFs)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if CheckEqualVec(got, expected) == false {
+				t.Errorf("%s: Answer with nil settings did not match with the explicit one.", name)
+			}
+		})
+	}
+
+	a := randomPoly(20)
+	b := randomPoly(7)
+	xs := randomPoly(9)
+	ys := randomPoly(9)
+	polys := map[string]func(fs *FFTSettings) []ff.Fr{
+		"PolyMul":  func(fs *FFTSettings) []ff.Fr { return fs.PolyMul(a, b) },
+		"PolyTree": func(fs *FFTSettings) []ff.Fr { return fs.PolyTree(xs) },
+		"PolyEvalMany": func(fs *FFTSettings) []ff.Fr {
+			return fs.PolyEvalMany(a, xs)
+		},
+		"PolyDiv": func(fs *FFTSettings) []ff.Fr {
+			q, _ := fs.PolyDiv(a, b)
+			return q
+		},
+		"PolyDivNewton": func(fs *FFTSettings) []ff.Fr {
+			q, _ := fs.PolyDivNewton(a, b)
+			return q
+		},
+		"XGCD": func(fs *FFTSettings) []ff.Fr {
+			_, u, _ := fs.XGCD(a, b)
+			return u
+		},
+		"XGCDUntil": func(fs *FFTSettings) []ff.Fr {
+			_, _, v := fs.XGCDUntil(a, b, 3)
+			return v
+		},
+		"PolyMultiEvaluate": func(fs *FFTSettings) []ff.Fr {
+			return fs.PolyMultiEvaluate(b, fs.SubProductTree(xs[:8]))
+		},
+		"PolyTreeVec":     func(fs *FFTSettings) []ff.Fr { return fs.PolyTreeVec([][]ff.Fr{xs, ys}) },
+		"PolyInterpolate": func(fs *FFTSettings) []ff.Fr { return fs.PolyInterpolate(xs, ys) },
+	}
+	for name, poly := range polys {
+		t.Run(name, func(t *testing.T) {
+			if CheckEqualVec(poly(nilFs), poly(fs)) == false {
+				t.Errorf("%s: Answer with nil settings did not match with the explicit one.", name)
+			}
+		})
+	}
+}
+
+func TestFFTSettingsTwoAdicity(t *testing.T) {
+	for _, c := range []ff.Curve{ff.BLS12_381, ff.BN254} {
+		t.Run(c.String(), func(t *testing.T) {
+			if err := ff.InitCurve(c); err != nil {
+				t.Fatal(err)
+			}
+			defer func() {
+				if err := ff.InitCurve(ff.BLS12_381); err != nil {
+					panic(err)
+				}
+			}()
+
+			maxScale := ff.TwoAdicity()
+			if _, err := TryNewFFTSettings(maxScale + 1); errors.Is(err, ErrDomainTooSmall) == false {
+				t.Errorf("TryNewFFTSettings: expected %v above the 2-adicity, got %v", ErrDomainTooSmall, err)
+			}
+			func() {
+				defer func() {
+					if recover() == nil {
+						t.Errorf("NewFFTSettings: expected a panic above the 2-adicity")
+					}
+				}()
+				NewFFTSettings(maxScale + 1)
+			}()
+
+			// The cache must not hand out settings built for another curve.
+			fs := GetFFTSettings(4)
+			if fs.RootOfUnity.IsEqual(&ff.Scale2RootOfUnity[4]) == false {
+				t.Errorf("GetFFTSettings: settings do not use the roots of unity of %s", c)
+			}
+
+			a := ff.FromInt64Vec([]int64{1, 2, 3, 4})
+			b := ff.FromInt64Vec([]int64{5, 1})
+			if CheckEqualVec(PolyMul(a, b), ff.FromInt64Vec([]int64{5, 11, 17, 23, 4})) == false {
+				t.Errorf("PolyMul: Answer did not match with expected.")
+			}
+			coeffs, err := fs.FFT(a, false)
+			if err != nil {
+				t.Fatal(err)
+			}
+			res, err := fs.FFT(coeffs, true)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if CheckEqualVec(res[:len(a)], a) == false {
+				t.Errorf("FFT: roundtrip did not match the input")
+			}
+		})
+	}
+}
@@ -5,11 +5,10 @@ import (
 	"math/bits"
 
 	"github.com/accumulators-agg/go-poly/ff"
-	gmcl "github.com/alinush/go-mcl"
 )
 
 // Returns true if polynomial A is a zero polynomial.
-func IsPolyZero(a []gmcl.Fr) bool {
+func IsPolyZero(a []ff.Fr) bool {
 
 	n := len(a)
 	if n == 0 {
@@ -24,19 +23,25 @@ func IsPolyZero(a []gmcl.Fr) bool {
 }
 
 // Returns true if polynomial A is a equal to polynomial B.
-func IsPolyEqual(a []gmcl.Fr, b []gmcl.Fr) bool {
+func IsPolyEqual(a []ff.Fr, b []ff.Fr) bool {
+	flag, err := TryIsPolyEqual(a, b)
+	if err != nil {
+		panic(err)
+	}
+	return flag
+}
+
+// Same as IsPolyEqual, but returns ErrEmptyInput instead of panicking.
+func TryIsPolyEqual(a []ff.Fr, b []ff.Fr) (bool, error) {
+	if len(a) == 0 || len(b) == 0 {
+		return false, fmt.Errorf("IsPolyEqual: %w", ErrEmptyInput)
+	}
 
 	p := PolyCondense(a)
 	q := PolyCondense(b)
 
-	if len(p) == 0 {
-		panic("IsPolyEqual: P is zero.")
-	}
-	if len(q) == 0 {
-		panic("IsPolyEqual: Q is zero.")
-	}
 	if len(p) != len(q) {
-		return false
+		return false, nil
 	}
 
 	var flag bool
@@ -45,16 +50,25 @@ func IsPolyEqual(a []gmcl.Fr, b []gmcl.Fr) bool {
 	for i := 0; i < len(p) && flag == true; i++ {
 		flag = flag && p[i] == q[i]
 	}
-	return flag
+	return flag, nil
 }
 
 // Removes extraneous zero entries from in vector representation of polynomial.
 // Example - Degree-4 Polynomial: [0, 1, 2, 3, 4, 0, 0, 0, 0] -> [0, 1, 2, 3, 4]
 // Note: Simplest condensed form is a zero polynomial of vector form: [0]
-func PolyCondense(a []gmcl.Fr) []gmcl.Fr {
+func PolyCondense(a []ff.Fr) []ff.Fr {
+	c, err := TryPolyCondense(a)
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
+
+// Same as PolyCondense, but returns ErrEmptyInput instead of panicking.
+func TryPolyCondense(a []ff.Fr) ([]ff.Fr, error) {
 	n := len(a)
 	if n == 0 {
-		panic("PolyCondense Error")
+		return nil, fmt.Errorf("PolyCondense: %w", ErrEmptyInput)
 	}
 
 	i := n
@@ -64,11 +78,11 @@ func PolyCondense(a []gmcl.Fr) []gmcl.Fr {
 		}
 		i--
 	}
-	return a[:i]
+	return a[:i], nil
 }
 
 // Computes the standard polynomial addition, polynomial A + polynomial B, and stores result in polynomial C.
-func PolyAdd(a []gmcl.Fr, b []gmcl.Fr) []gmcl.Fr {
+func PolyAdd(a []ff.Fr, b []ff.Fr) []ff.Fr {
 
 	if IsPolyZero(a) {
 		return PolyCondense(b)
@@ -81,14 +95,14 @@ func PolyAdd(a []gmcl.Fr, b []gmcl.Fr) []gmcl.Fr {
 	aLen := len(a)
 	bLen := len(b)
 	n := ff.Max(aLen, bLen)
-	c := make([]gmcl.Fr, n, n)
+	c := make([]ff.Fr, n, n)
 
 	for i := 0; i < n; i++ {
 		if i < aLen {
-			gmcl.FrAdd(&c[i], &c[i], &a[i])
+			ff.FrAdd(&c[i], &c[i], &a[i])
 		}
 		if i < bLen {
-			gmcl.FrAdd(&c[i], &c[i], &b[i])
+			ff.FrAdd(&c[i], &c[i], &b[i])
 		}
 	}
 	c = PolyCondense(c)
@@ -96,7 +110,7 @@ func PolyAdd(a []gmcl.Fr, b []gmcl.Fr) []gmcl.Fr {
 }
 
 // Computes the standard polynomial subtraction, polynomial A - polynomial B, and stores result in polynomial C.
-func PolySub(a []gmcl.Fr, b []gmcl.Fr) []gmcl.Fr {
+func PolySub(a []ff.Fr, b []ff.Fr) []ff.Fr {
 
 	if IsPolyZero(b) {
 		return a
@@ -105,14 +119,14 @@ func PolySub(a []gmcl.Fr, b []gmcl.Fr) []gmcl.Fr {
 	aLen := len(a)
 	bLen := len(b)
 	n := ff.Max(aLen, bLen)
-	c := make([]gmcl.Fr, n, n)
+	c := make([]ff.Fr, n, n)
 
 	for i := 0; i < n; i++ {
 		if i < aLen {
-			gmcl.FrAdd(&c[i], &c[i], &a[i])
+			ff.FrAdd(&c[i], &c[i], &a[i])
 		}
 		if i < bLen {
-			gmcl.FrSub(&c[i], &c[i], &b[i])
+			ff.FrSub(&c[i], &c[i], &b[i])
 		}
 	}
 	c = PolyCondense(c)
@@ -120,84 +134,117 @@ func PolySub(a []gmcl.Fr, b []gmcl.Fr) []gmcl.Fr {
 }
 
 // Compute a(x) * b(x)
-func PolyMul(a []gmcl.Fr, b []gmcl.Fr) []gmcl.Fr {
+func PolyMul(a []ff.Fr, b []ff.Fr) []ff.Fr {
+	return cachedSettings.PolyMul(a, b)
+}
+
+// Same as PolyMul, using the roots of unity of fs.
+func (fs *FFTSettings) PolyMul(a []ff.Fr, b []ff.Fr) []ff.Fr {
+	c, err := fs.TryPolyMul(a, b)
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
+
+// Same as PolyMul, but returns ErrEmptyInput or the FFT error instead of panicking.
+func TryPolyMul(a []ff.Fr, b []ff.Fr) ([]ff.Fr, error) {
+	return cachedSettings.TryPolyMul(a, b)
+}
+
+// Same as TryPolyMul, using the roots of unity of fs.
+// Returns ErrDomainTooSmall if fs is not wide enough for the product.
+func (fs *FFTSettings) TryPolyMul(a []ff.Fr, b []ff.Fr) ([]ff.Fr, error) {
+	if len(a) == 0 || len(b) == 0 {
+		return nil, fmt.Errorf("PolyMul: %w", ErrEmptyInput)
+	}
 	if IsPolyZero(a) || IsPolyZero(b) {
-		return []gmcl.Fr{ff.ZERO}
+		return []ff.Fr{ff.ZERO}, nil
 	}
 
 	aLen := len(a)
 	bLen := len(b)
 	if aLen == bLen && aLen == 1 {
-		c := make([]gmcl.Fr, 1, 1)
-		gmcl.FrMul(&c[0], &a[0], &b[0])
-		return c
+		c := make([]ff.Fr, 1, 1)
+		ff.FrMul(&c[0], &a[0], &b[0])
+		return c, nil
 	}
 	n := uint64(2 * ff.Max(aLen, bLen))
 	n = nextPowOf2(n)
 
-	var padding []gmcl.Fr
+	// Fresh zero-padded copies, so the FFTs can work in place.
+	evalsA := make([]ff.Fr, n, n)
+	copy(evalsA, a)
+	evalsB := make([]ff.Fr, n, n)
+	copy(evalsB, b)
 
-	padding = make([]gmcl.Fr, n-uint64(aLen), n-uint64(aLen))
-	a = append(a, padding...)
-
-	padding = make([]gmcl.Fr, n-uint64(bLen), n-uint64(bLen))
-	b = append(b, padding...)
-
-	l := uint8(bits.Len64(n)) - 1 // n = 8 => 3 or 4?
-	fs := NewFFTSettings(l)
+	settings, err := fs.forWidth(n)
+	if err != nil {
+		return nil, fmt.Errorf("PolyMul: %w", err)
+	}
 
-	evalsA, _ := fs.FFT(a, false)
-	evalsB, _ := fs.FFT(b, false)
+	// DIF -> pointwise -> DIT, the evaluations stay in bit-reversed order.
+	if err := settings.InplaceFFTDIFNoPermute(evalsA, false); err != nil {
+		return nil, err
+	}
+	if err := settings.InplaceFFTDIFNoPermute(evalsB, false); err != nil {
+		return nil, err
+	}
+	for i := uint64(0); i < n; i++ {
+		ff.FrMul(&evalsA[i], &evalsA[i], &evalsB[i])
+	}
+	if err := settings.InplaceFFTDITNoPermute(evalsA, true); err != nil {
+		return nil, err
+	}
 
-	res, _ := fs.FFT(ff.MulVecFr(evalsA, evalsB), true)
-	res = res[:(aLen + bLen - 1)]
+	res := evalsA[:(aLen + bLen - 1)]
 	res = PolyCondense(res)
-	return res
+	return res, nil
 }
 
 // Builds the polynomial from its roots
 // (x - a_1)(x - a_2)(x - a_3)(x - a_4)(x - a_5)(1)(1)(1)
 // Need not be a power of two
-func PolyTree(a []gmcl.Fr) []gmcl.Fr {
+func PolyTree(a []ff.Fr) []ff.Fr {
+	return cachedSettings.PolyTree(a)
+}
+
+// Same as PolyTree, using the roots of unity of fs.
+func (fs *FFTSettings) PolyTree(a []ff.Fr) []ff.Fr {
 
 	n := uint64(len(a))
 	aLen := n
 	n = nextPowOf2(n)
 
-	var padding []gmcl.Fr
-
-	padding = make([]gmcl.Fr, n-aLen, n-aLen)
-	a = append(a, padding...)
-
 	l := uint8(bits.Len64(n)) - 1
 
-	var M [][]gmcl.Fr
+	var M [][]ff.Fr
 
-	M = make([][]gmcl.Fr, n, n)
+	M = make([][]ff.Fr, n, n)
 	for j := uint64(0); j < n; j++ {
 		if j < aLen {
-			M[j] = make([]gmcl.Fr, 2)
-			gmcl.FrNeg(&M[j][0], &a[j])
+			M[j] = make([]ff.Fr, 2)
+			ff.FrNeg(&M[j][0], &a[j])
 			ff.IntAsFr(&M[j][1], 1)
 		} else {
-			M[j] = make([]gmcl.Fr, 1)
+			M[j] = make([]ff.Fr, 1)
 			M[j][0].SetInt64(1)
 		}
 	}
 
-	var x []gmcl.Fr
-	var y []gmcl.Fr
+	var x []ff.Fr
+	var y []ff.Fr
 	var index int64
 	index = 0
 	for i := uint8(1); i <= l; i++ {
 		L := uint64(1) << (l - i)
-		m := make([][]gmcl.Fr, L, L)
+		m := make([][]ff.Fr, L, L)
 		for j := uint64(0); j < L; j++ {
 			x = M[index]
 			index++
 			y = M[index]
 			index++
-			m[j] = PolyMul(x, y)
+			m[j] = fs.PolyMul(x, y)
 		}
 		index = 0
 		M = m
@@ -205,75 +252,120 @@ func PolyTree(a []gmcl.Fr) []gmcl.Fr {
 	return PolyCondense(M[0])
 }
 
-// Invert the divisor, then multiply
-func polyFactorDiv(dst *gmcl.Fr, a *gmcl.Fr, b *gmcl.Fr) {
-	// TODO: use divmod instead.
-	var tmp gmcl.Fr
-	gmcl.FrInv(&tmp, b)
-	gmcl.FrMul(dst, &tmp, a)
+// Long polynomial division for two polynomials in coefficient form
+func PolyLongDiv(A []ff.Fr, B []ff.Fr) []ff.Fr {
+	q, err := TryPolyLongDiv(A, B)
+	if err != nil {
+		panic(err)
+	}
+	return q
 }
 
-// Long polynomial division for two polynomials in coefficient form
-// Need to check divide by zero
-func PolyLongDiv(A []gmcl.Fr, B []gmcl.Fr) []gmcl.Fr {
+// Same as PolyLongDiv, but returns ErrEmptyInput, ErrDivideByZero or ErrDegreeMismatch instead of panicking.
+func TryPolyLongDiv(A []ff.Fr, B []ff.Fr) ([]ff.Fr, error) {
+	if len(A) == 0 || len(B) == 0 {
+		return nil, fmt.Errorf("PolyLongDiv: %w", ErrEmptyInput)
+	}
 	if IsPolyZero(B) == true {
-		panic("PolyLongDiv: Cannot divide by zero polynomial.")
+		return nil, fmt.Errorf("PolyLongDiv: %w", ErrDivideByZero)
+	}
+	B = PolyCondense(B)
+	if len(B) > len(A) {
+		return nil, fmt.Errorf("PolyLongDiv: %w: Deg(B) should be <= Deg(A)", ErrDegreeMismatch)
 	}
-	a := make([]gmcl.Fr, len(A), len(A))
+	a := make([]ff.Fr, len(A), len(A))
 	for i := 0; i < len(a); i++ {
 		ff.CopyFr(&a[i], &A[i])
 	}
 	aPos := len(a) - 1
 	bPos := len(B) - 1
 	diff := aPos - bPos
-	out := make([]gmcl.Fr, diff+1, diff+1)
+	out := make([]ff.Fr, diff+1, diff+1)
+	// Every quotient term is divided by the same leading coefficient
+	var lcInv ff.Fr
+	ff.FrInv(&lcInv, &B[bPos])
 	for diff >= 0 {
 		quot := &out[diff]
-		polyFactorDiv(quot, &a[aPos], &B[bPos])
-		var tmp, tmp2 gmcl.Fr
+		ff.FrMul(quot, &a[aPos], &lcInv)
+		var tmp, tmp2 ff.Fr
 		for i := bPos; i >= 0; i-- {
 			// In steps: a[diff + i] -= b[i] * quot
 			// tmp =  b[i] * quot
-			gmcl.FrMul(&tmp, quot, &B[i])
+			ff.FrMul(&tmp, quot, &B[i])
 			// tmp2 = a[diff + i] - tmp
-			gmcl.FrSub(&tmp2, &a[diff+i], &tmp)
+			ff.FrSub(&tmp2, &a[diff+i], &tmp)
 			// a[diff + i] = tmp2
 			ff.CopyFr(&a[diff+i], &tmp2)
 		}
 		aPos -= 1
 		diff -= 1
 	}
-	return out
+	return out, nil
 }
 
 // Computes q(x) and r(x) s.t. a(x) = q(x) * b(x) + r(x)
-func PolyDiv(A []gmcl.Fr, B []gmcl.Fr) ([]gmcl.Fr, []gmcl.Fr) {
-	if IsPolyZero(B) == true {
-		panic("PolyDiv: Cannot divide by zero polynomial.")
+func PolyDiv(A []ff.Fr, B []ff.Fr) ([]ff.Fr, []ff.Fr) {
+	return cachedSettings.PolyDiv(A, B)
+}
+
+// Same as PolyDiv, using the roots of unity of fs.
+func (fs *FFTSettings) PolyDiv(A []ff.Fr, B []ff.Fr) ([]ff.Fr, []ff.Fr) {
+	q, r, err := fs.TryPolyDiv(A, B)
+	if err != nil {
+		panic(err)
 	}
+	return q, r
+}
 
+// Same as PolyDiv, but returns ErrEmptyInput, ErrDivideByZero or ErrDegreeMismatch instead of panicking.
+func TryPolyDiv(A []ff.Fr, B []ff.Fr) ([]ff.Fr, []ff.Fr, error) {
+	return cachedSettings.TryPolyDiv(A, B)
+}
+
+// Same as TryPolyDiv, using the roots of unity of fs.
+// Returns ErrDomainTooSmall if fs is not wide enough for the fast division.
+func (fs *FFTSettings) TryPolyDiv(A []ff.Fr, B []ff.Fr) ([]ff.Fr, []ff.Fr, error) {
+	if len(A) == 0 || len(B) == 0 {
+		return nil, nil, fmt.Errorf("PolyDiv: %w", ErrEmptyInput)
+	}
+	if IsPolyZero(B) == true {
+		return nil, nil, fmt.Errorf("PolyDiv: %w", ErrDivideByZero)
+	}
+	B = PolyCondense(B)
 	if len(B) > len(A) {
-		panic("PolyDiv: Deg(B) should be <= Ded(A)")
+		return nil, nil, fmt.Errorf("PolyDiv: %w: Deg(B) should be <= Deg(A)", ErrDegreeMismatch)
+	}
+
+	if polyDivUseNewton(len(A), len(B)) {
+		// No operand of the products in the Newton division is longer than A.
+		if err := fs.checkMulWidth(len(A)); err != nil {
+			return nil, nil, fmt.Errorf("PolyDiv: %w", err)
+		}
+		q, r := fs.PolyDivNewton(A, B)
+		return q, r, nil
 	}
 
-	a := make([]gmcl.Fr, len(A), len(A))
+	a := make([]ff.Fr, len(A), len(A))
 	for i := 0; i < len(a); i++ {
 		ff.CopyFr(&a[i], &A[i])
 	}
 	aPos := len(a) - 1
 	bPos := len(B) - 1
 	diff := aPos - bPos
-	out := make([]gmcl.Fr, diff+1, diff+1)
+	out := make([]ff.Fr, diff+1, diff+1)
+	// Every quotient term is divided by the same leading coefficient
+	var lcInv ff.Fr
+	ff.FrInv(&lcInv, &B[bPos])
 	for diff >= 0 {
 		quot := &out[diff]
-		polyFactorDiv(quot, &a[aPos], &B[bPos])
-		var tmp, tmp2 gmcl.Fr
+		ff.FrMul(quot, &a[aPos], &lcInv)
+		var tmp, tmp2 ff.Fr
 		for i := bPos; i >= 0; i-- {
 			// In steps: a[diff + i] -= b[i] * quot
 			// tmp =  b[i] * quot
-			gmcl.FrMul(&tmp, quot, &B[i])
+			ff.FrMul(&tmp, quot, &B[i])
 			// tmp2 = a[diff + i] - tmp
-			gmcl.FrSub(&tmp2, &a[diff+i], &tmp)
+			ff.FrSub(&tmp2, &a[diff+i], &tmp)
 			// a[diff + i] = tmp2
 			ff.CopyFr(&a[diff+i], &tmp2)
 		}
@@ -282,7 +374,7 @@ func PolyDiv(A []gmcl.Fr, B []gmcl.Fr) ([]gmcl.Fr, []gmcl.Fr) {
 	}
 	out = PolyCondense(out)
 	a = PolyCondense(a)
-	return out, a
+	return out, a, nil
 }
 
 // Given M(x) computes  M'(x)
@@ -290,47 +382,70 @@ func PolyDiv(A []gmcl.Fr, B []gmcl.Fr) ([]gmcl.Fr, []gmcl.Fr) {
 // This [paper](https://arxiv.org/pdf/2002.10304.pdf) claims there is a faster way to do this
 // Page 10 under interpolation
 // Not sure how it is different from doing subproduct tree on M'(x)
-func PolyDifferentiate(a []gmcl.Fr) []gmcl.Fr {
+func PolyDifferentiate(a []ff.Fr) []ff.Fr {
+	c, err := TryPolyDifferentiate(a)
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
+
+// Same as PolyDifferentiate, but returns ErrEmptyInput instead of panicking.
+func TryPolyDifferentiate(a []ff.Fr) ([]ff.Fr, error) {
 	n := int64(len(a))
 	if n == 0 {
-		panic("PolyDifferentiate: Input is empty")
+		return nil, fmt.Errorf("PolyDifferentiate: %w", ErrEmptyInput)
 	}
 	if n == 1 {
-		return make([]gmcl.Fr, 1)
+		return make([]ff.Fr, 1), nil
 	}
-	c := make([]gmcl.Fr, n, n)
-	var temp gmcl.Fr
+	c := make([]ff.Fr, n, n)
+	var temp ff.Fr
 	for i := int64(1); i < n; i++ {
 		ff.IntAsFr(&temp, i)
-		gmcl.FrMul(&c[i], &a[i], &temp)
+		ff.FrMul(&c[i], &a[i], &temp)
 	}
-	return c[1:]
+	return c[1:], nil
 }
 
 // Extended GCG: Computes u(x) and v(x) s.t. u(x) * a(x) + v(x) * b(x) = g(x)
-func XGCD(a []gmcl.Fr, b []gmcl.Fr) (g []gmcl.Fr, u []gmcl.Fr, v []gmcl.Fr) {
-	return xGCD2(a, b)
+// Large inputs use the half-GCD, small ones the quadratic Euclidean loop.
+func XGCD(a []ff.Fr, b []ff.Fr) (g []ff.Fr, u []ff.Fr, v []ff.Fr) {
+	return cachedSettings.XGCD(a, b)
+}
+
+// Same as XGCD, using the roots of unity of fs.
+func (fs *FFTSettings) XGCD(a []ff.Fr, b []ff.Fr) (g []ff.Fr, u []ff.Fr, v []ff.Fr) {
+	if ff.Min(len(a), len(b)) >= xgcdHalfThreshold {
+		return fs.xGCDHalf(a, b)
+	}
+	return fs.xGCD2(a, b)
 }
 
 // Computes Extended GCD using pseudocode **#1** here:
 // https://en.wikipedia.org/w/index.php?title=Extended_Euclidean_algorithm&oldid=1003613686
 // a * u + b * v = g
-func xGCD1(a []gmcl.Fr, b []gmcl.Fr) (g []gmcl.Fr, u []gmcl.Fr, v []gmcl.Fr) {
+func xGCD1(a []ff.Fr, b []ff.Fr) (g []ff.Fr, u []ff.Fr, v []ff.Fr) {
+	return cachedSettings.xGCD1(a, b)
+}
+
+// Same as xGCD1, using the roots of unity of fs.
+func (fs *FFTSettings) xGCD1(a []ff.Fr, b []ff.Fr) (g []ff.Fr, u []ff.Fr, v []ff.Fr) {
 
 	if len(b) > len(a) {
-		g, v, u := xGCD1(b, a)
+		g, v, u := fs.xGCD1(b, a)
 		return g, u, v
 	}
 
 	old_r, r := a, b
-	old_s, s := []gmcl.Fr{ff.ONE}, []gmcl.Fr{ff.ZERO}
-	old_t, t := []gmcl.Fr{ff.ZERO}, []gmcl.Fr{ff.ONE}
+	old_s, s := []ff.Fr{ff.ONE}, []ff.Fr{ff.ZERO}
+	old_t, t := []ff.Fr{ff.ZERO}, []ff.Fr{ff.ONE}
 
 	for IsPolyZero(r) == false {
-		quotient, remainder := PolyDiv(old_r, r)
+		quotient, remainder := fs.PolyDiv(old_r, r)
 		old_r, r = r, remainder
-		old_s, s = s, PolySub(old_s, PolyMul(quotient, s))
-		old_t, t = t, PolySub(old_t, PolyMul(quotient, t))
+		old_s, s = s, PolySub(old_s, fs.PolyMul(quotient, s))
+		old_t, t = t, PolySub(old_t, fs.PolyMul(quotient, t))
 	}
 
 	old_r = PolyCondense(old_r)
@@ -342,34 +457,103 @@ func xGCD1(a []gmcl.Fr, b []gmcl.Fr) (g []gmcl.Fr, u []gmcl.Fr, v []gmcl.Fr) {
 // Computes Extended GCD using pseudocode **#2** here:
 // https://en.wikipedia.org/w/index.php?title=Extended_Euclidean_algorithm&oldid=1003613686
 // a * u + b * v = g
-func xGCD2(a []gmcl.Fr, b []gmcl.Fr) (g []gmcl.Fr, u []gmcl.Fr, v []gmcl.Fr) {
+func xGCD2(a []ff.Fr, b []ff.Fr) (g []ff.Fr, u []ff.Fr, v []ff.Fr) {
+	return cachedSettings.xGCD2(a, b)
+}
+
+// Same as xGCD2, using the roots of unity of fs.
+func (fs *FFTSettings) xGCD2(a []ff.Fr, b []ff.Fr) (g []ff.Fr, u []ff.Fr, v []ff.Fr) {
 
 	if len(b) > len(a) {
-		g, v, u := xGCD2(b, a)
+		g, v, u := fs.xGCD2(b, a)
 		return g, u, v
 	} else {
-		s := []gmcl.Fr{ff.ZERO}
-		old_s := []gmcl.Fr{ff.ONE}
+		s := []ff.Fr{ff.ZERO}
+		old_s := []ff.Fr{ff.ONE}
 
 		r := b
 		old_r := a
 
 		for IsPolyZero(r) == false {
-			quotient, remainder := PolyDiv(old_r, r)
+			quotient, remainder := fs.PolyDiv(old_r, r)
 			old_r, r = r, remainder
-			old_s, s = s, PolySub(old_s, PolyMul(quotient, s))
+			old_s, s = s, PolySub(old_s, fs.PolyMul(quotient, s))
 		}
 
-		var bezout_t []gmcl.Fr
+		var bezout_t []ff.Fr
 		if IsPolyZero(b) == false {
-			bezout_t, _ = PolyDiv(PolySub(old_r, PolyMul(old_s, a)), b)
+			bezout_t, _ = fs.PolyDiv(PolySub(old_r, fs.PolyMul(old_s, a)), b)
 		} else {
-			bezout_t = []gmcl.Fr{ff.ZERO}
+			bezout_t = []ff.Fr{ff.ZERO}
 		}
 		return old_r, old_s, bezout_t
 	}
 }
 
+// Partial extended GCD: runs the Euclidean remainder sequence a, b, r_2, ... of (a, b) only until
+// the first remainder r of degree < degBound, and returns it with its cofactors u(x) and v(x)
+// s.t. u(x) * a(x) + v(x) * b(x) = r(x). The cofactors are those of XGCD at that step,
+// as needed for rational reconstruction, Pade approximants and Reed-Solomon decoding.
+// Large inputs use the half-GCD, small ones the quadratic Euclidean loop.
+// Panics if degBound is negative.
+func XGCDUntil(a []ff.Fr, b []ff.Fr, degBound int) (r []ff.Fr, u []ff.Fr, v []ff.Fr) {
+	return cachedSettings.XGCDUntil(a, b, degBound)
+}
+
+// Same as XGCDUntil, using the roots of unity of fs.
+func (fs *FFTSettings) XGCDUntil(a []ff.Fr, b []ff.Fr, degBound int) (r []ff.Fr, u []ff.Fr, v []ff.Fr) {
+	_, r, M := fs.xGCDUntilState(a, b, degBound)
+	return r, M[1][0], M[1][1]
+}
+
+// Runs XGCDUntil and returns its state, to continue the remainder sequence from there:
+// the remainder r1 of XGCDUntil, the one before it r0, and their cofactors M s.t. M * (a, b) = (r0, r1).
+func (fs *FFTSettings) xGCDUntilState(a []ff.Fr, b []ff.Fr, degBound int) (r0 []ff.Fr, r1 []ff.Fr, M polyMatrix) {
+	if degBound < 0 {
+		msg := fmt.Sprintf("XGCDUntil: Got a negative degree bound %d", degBound)
+		panic(msg)
+	}
+	if ff.Min(len(a), len(b)) >= xgcdHalfThreshold {
+		return fs.xGCDUntilHalf(a, b, degBound)
+	}
+	return fs.xGCDUntil1(a, b, degBound)
+}
+
+// Computes XGCDUntil with the Euclidean steps of xGCD1.
+// a * u + b * v = r
+func xGCDUntil1(a []ff.Fr, b []ff.Fr, degBound int) (r []ff.Fr, u []ff.Fr, v []ff.Fr) {
+	_, r, M := cachedSettings.xGCDUntil1(a, b, degBound)
+	return r, M[1][0], M[1][1]
+}
+
+// Same as xGCDUntil1, using the roots of unity of fs. Returns the state of xGCDUntilState.
+func (fs *FFTSettings) xGCDUntil1(a []ff.Fr, b []ff.Fr, degBound int) (r0 []ff.Fr, r1 []ff.Fr, M polyMatrix) {
+
+	a, b = PolyCondense(a), PolyCondense(b)
+	if len(b) > len(a) {
+		r0, r1, M = fs.xGCDUntil1(b, a, degBound)
+		M[0][0], M[0][1] = M[0][1], M[0][0]
+		M[1][0], M[1][1] = M[1][1], M[1][0]
+		return r0, r1, M
+	}
+
+	old_r, r := a, b
+	old_s, s := []ff.Fr{ff.ONE}, []ff.Fr{ff.ZERO}
+	old_t, t := []ff.Fr{ff.ZERO}, []ff.Fr{ff.ONE}
+	if polyDegree(old_r) < degBound {
+		// a is the remainder, b comes before it
+		return b, a, polyMatrix{{s, t}, {old_s, old_t}}
+	}
+
+	for polyDegree(r) >= degBound {
+		quotient, remainder := fs.PolyDiv(old_r, r)
+		old_r, r = r, remainder
+		old_s, s = s, PolySub(old_s, fs.PolyMul(quotient, s))
+		old_t, t = t, PolySub(old_t, fs.PolyMul(quotient, t))
+	}
+	return old_r, r, polyMatrix{{PolyCondense(old_s), PolyCondense(old_t)}, {PolyCondense(s), PolyCondense(t)}}
+}
+
 // SubProdTree
 // Needs to be power of two
 // (x - a_1)(x - a_2)(x - a_3)(x - a_4)(x - a_5)(x - a_6)(x - a_7)(x - a_8)
@@ -377,38 +561,80 @@ func xGCD2(a []gmcl.Fr, b []gmcl.Fr) (g []gmcl.Fr, u []gmcl.Fr, v []gmcl.Fr) {
 // Index 1 has N/2 elements
 // Index 2 hash N/4 elements
 // Thus this is an inverted tree.
-func SubProductTree(a []gmcl.Fr) [][][]gmcl.Fr {
+func SubProductTree(a []ff.Fr) [][][]ff.Fr {
+	return cachedSettings.SubProductTree(a)
+}
 
-	n := uint64(len(a))
+// Same as SubProductTree, using the roots of unity of fs.
+func (fs *FFTSettings) SubProductTree(a []ff.Fr) [][][]ff.Fr {
+	M, err := fs.TrySubProductTree(a)
+	if err != nil {
+		panic(err)
+	}
+	return M
+}
+
+// Same as SubProductTree, but returns ErrEmptyInput or ErrNotPowerOfTwo instead of panicking.
+func TrySubProductTree(a []ff.Fr) ([][][]ff.Fr, error) {
+	return cachedSettings.TrySubProductTree(a)
+}
+
+// Same as TrySubProductTree, using the roots of unity of fs.
+// Returns ErrDomainTooSmall if fs is not wide enough for the root of the tree.
+func (fs *FFTSettings) TrySubProductTree(a []ff.Fr) ([][][]ff.Fr, error) {
 
+	n := uint64(len(a))
+	if n == 0 {
+		return nil, fmt.Errorf("SubProductTree: %w", ErrEmptyInput)
+	}
 	if ff.IsPowerOfTwo(n) == false {
-		panic("SubProductTree inputs needs to be power of two")
+		return nil, fmt.Errorf("SubProductTree: %w: got %d points", ErrNotPowerOfTwo, n)
+	}
+	// The root is the product of two children of n/2 + 1 coefficients.
+	if err := fs.checkMulWidth(int(n/2) + 1); err != nil {
+		return nil, fmt.Errorf("SubProductTree: %w", err)
 	}
 
+	return fs.subProductTree(a), nil
+}
+
+// Same as SubProductTree, but need not be a power of two.
+// The tree is padded to the next power of two with constant (1) leaves, like PolyTree.
+// (x - a_1)(x - a_2)(x - a_3)(x - a_4)(x - a_5)(1)(1)(1)
+func (fs *FFTSettings) subProductTree(a []ff.Fr) [][][]ff.Fr {
+
+	aLen := uint64(len(a))
+	n := nextPowOf2(aLen)
+
 	l := uint8(bits.Len64(n)) - 1
 	// fmt.Println(l)
-	var M [][][]gmcl.Fr
-	M = make([][][]gmcl.Fr, l+1, l+1)
-	M[0] = make([][]gmcl.Fr, n, n)
+	var M [][][]ff.Fr
+	M = make([][][]ff.Fr, l+1, l+1)
+	M[0] = make([][]ff.Fr, n, n)
 	for j := uint64(0); j < n; j++ {
-		M[0][j] = make([]gmcl.Fr, 2)
-		gmcl.FrNeg(&M[0][j][0], &a[j])
-		ff.IntAsFr(&M[0][j][1], 1)
+		if j < aLen {
+			M[0][j] = make([]ff.Fr, 2)
+			ff.FrNeg(&M[0][j][0], &a[j])
+			ff.IntAsFr(&M[0][j][1], 1)
+		} else {
+			M[0][j] = make([]ff.Fr, 1)
+			M[0][j][0].SetInt64(1)
+		}
 	}
 
-	var x []gmcl.Fr
-	var y []gmcl.Fr
+	var x []ff.Fr
+	var y []ff.Fr
 	var index int64
 	index = 0
 	for i := uint8(1); i <= l; i++ {
 		L := uint64(1) << (l - i)
-		M[i] = make([][]gmcl.Fr, L, L)
+		M[i] = make([][]ff.Fr, L, L)
 		for j := uint64(0); j < L; j++ {
 			x = M[i-1][index]
 			index++
 			y = M[i-1][index]
 			index++
-			M[i][j] = PolyMul(x, y)
+			M[i][j] = fs.PolyMul(x, y)
 		}
 		index = 0
 	}
@@ -416,40 +642,111 @@ func SubProductTree(a []gmcl.Fr) [][][]gmcl.Fr {
 	return M
 }
 
+// Computes f(x) mod m(x), skipping the division when deg(f) < deg(m)
+func (fs *FFTSettings) polyRemainder(f []ff.Fr, m []ff.Fr) ([]ff.Fr, error) {
+	f = PolyCondense(f)
+	if len(f) < len(m) {
+		return f, nil
+	}
+	_, r, err := fs.TryPolyDiv(f, m)
+	return r, err
+}
+
 // Fast multi-point evaluation using subproduct tree
-// For n^ directly use EvalPolyAt $n$ times
-func PolyMultiEvaluate(f []gmcl.Fr, M [][][]gmcl.Fr) []gmcl.Fr {
-	n := int64(len(f))
-	if n == 0 {
-		panic("PolyDifferentiate: Input is empty")
+// Subtrees with few leaves are evaluated directly with Horner's rule, see PolyEvalMany.
+func PolyMultiEvaluate(f []ff.Fr, M [][][]ff.Fr) []ff.Fr {
+	return cachedSettings.PolyMultiEvaluate(f, M)
+}
+
+// Same as PolyMultiEvaluate, using the roots of unity of fs.
+func (fs *FFTSettings) PolyMultiEvaluate(f []ff.Fr, M [][][]ff.Fr) []ff.Fr {
+	e, err := fs.TryPolyMultiEvaluate(f, M)
+	if err != nil {
+		panic(err)
 	}
-	if n == 1 {
-		return f
+	return e
+}
+
+// Same as PolyMultiEvaluate, but returns ErrEmptyInput, ErrDegreeMismatch or ErrDivideByZero
+// instead of panicking, also when M is not shaped like a subproduct tree.
+func TryPolyMultiEvaluate(f []ff.Fr, M [][][]ff.Fr) ([]ff.Fr, error) {
+	return cachedSettings.TryPolyMultiEvaluate(f, M)
+}
+
+// Same as TryPolyMultiEvaluate, using the roots of unity of fs.
+func (fs *FFTSettings) TryPolyMultiEvaluate(f []ff.Fr, M [][][]ff.Fr) ([]ff.Fr, error) {
+	if len(f) == 0 || len(M) == 0 {
+		return nil, fmt.Errorf("PolyMultiEvaluate: %w", ErrEmptyInput)
 	}
+	k := len(M) - 1
+	for i := 0; i <= k; i++ {
+		if len(M[i]) != 1<<(k-i) {
+			return nil, fmt.Errorf("PolyMultiEvaluate: %w: level %d of the subproduct tree has %d nodes, expected %d",
+				ErrDegreeMismatch, i, len(M[i]), 1<<(k-i))
+		}
+		for j := range M[i] {
+			if len(M[i][j]) == 0 {
+				return nil, fmt.Errorf("PolyMultiEvaluate: %w: node %d of level %d", ErrEmptyInput, j, i)
+			}
+		}
+	}
+	return fs.polyMultiEvaluate(f, M)
+}
+
+func (fs *FFTSettings) polyMultiEvaluate(f []ff.Fr, M [][][]ff.Fr) ([]ff.Fr, error) {
+	n := int64(len(f))
 
 	k := len(M) - 1
+	if k == 0 {
+		// Leaf: f(x) mod (x - a) = f(a)
+		r, err := fs.polyRemainder(f, M[0][0])
+		if err != nil {
+			return nil, fmt.Errorf("PolyMultiEvaluate: %w", err)
+		}
+		return []ff.Fr{r[0]}, nil
+	}
 	if !(1<<k >= n) {
-		msg := fmt.Sprintf("Subproduct tree and the polynomial size did not match\n\t len(f): %d len(M): %d", n, 1<<k)
-		panic(msg)
+		return nil, fmt.Errorf("PolyMultiEvaluate: %w: Subproduct tree and the polynomial size did not match\n\t len(f): %d len(M): %d",
+			ErrDegreeMismatch, n, 1<<k)
+	}
+	if len(M[0]) < polyEvalTreeThreshold {
+		// Few leaves left: evaluating at each of them is cheaper than dividing further.
+		e, err := fs.polyEvalLeaves(f, M[0])
+		if err != nil {
+			return nil, fmt.Errorf("PolyMultiEvaluate: %w", err)
+		}
+		return e, nil
+	}
+	aL, err := fs.polyRemainder(f, M[k-1][0])
+	if err != nil {
+		return nil, fmt.Errorf("PolyMultiEvaluate: %w", err)
+	}
+	aR, err := fs.polyRemainder(f, M[k-1][1])
+	if err != nil {
+		return nil, fmt.Errorf("PolyMultiEvaluate: %w", err)
 	}
-	_, aL := PolyDiv(f, M[k-1][0])
-	_, aR := PolyDiv(f, M[k-1][1])
 
 	mL, mR := splitSubProdTree(M)
 
-	l := PolyMultiEvaluate(aL, mL)
-	r := PolyMultiEvaluate(aR, mR)
+	l, err := fs.polyMultiEvaluate(aL, mL)
+	if err != nil {
+		return nil, err
+	}
+	r, err := fs.polyMultiEvaluate(aR, mR)
+	if err != nil {
+		return nil, err
+	}
 
-	return append(l, r...)
+	return append(l, r...), nil
 }
 
 // Grossly assumes that it is a proper tree
 // Given a SubProduct tree, divides into 2 sub-product trees
-func splitSubProdTree(M [][][]gmcl.Fr) ([][][]gmcl.Fr, [][][]gmcl.Fr) {
+func splitSubProdTree(M [][][]ff.Fr) ([][][]ff.Fr, [][][]ff.Fr) {
 
 	k := len(M) - 1
-	L := make([][][]gmcl.Fr, k)
-	R := make([][][]gmcl.Fr, k)
+	L := make([][][]ff.Fr, k)
+	R := make([][][]ff.Fr, k)
 
 	for i := 0; i < k; i++ {
 		n := len(M[i])
@@ -465,42 +762,48 @@ func splitSubProdTree(M [][][]gmcl.Fr) ([][][]gmcl.Fr, [][][]gmcl.Fr) {
 // let a = [poly1, poly2, poly3, poly4]
 // Returns \prod_{i=0}^{N-1}a_i
 // Need NOT be a power of two
-func PolyTreeVec(a [][]gmcl.Fr) []gmcl.Fr {
+func PolyTreeVec(a [][]ff.Fr) []ff.Fr {
+	return cachedSettings.PolyTreeVec(a)
+}
+
+// Same as PolyTreeVec, using the roots of unity of fs.
+func (fs *FFTSettings) PolyTreeVec(a [][]ff.Fr) []ff.Fr {
 
 	n := uint64(len(a))
 	aLen := n
 	n = nextPowOf2(n)
 
-	var padding [][]gmcl.Fr
+	var padding [][]ff.Fr
 
-	padding = make([][]gmcl.Fr, n-aLen, n-aLen)
+	padding = make([][]ff.Fr, n-aLen, n-aLen)
 	for i := range padding {
-		padding[i] = make([]gmcl.Fr, 1, 1)
+		padding[i] = make([]ff.Fr, 1, 1)
 		padding[i][0].SetInt64(1)
 	}
-	a = append(a, padding...)
+	// Cap a so that the padding never lands in the caller's backing array
+	a = append(a[:aLen:aLen], padding...)
 
 	l := uint8(bits.Len64(n)) - 1
 
-	var M [][]gmcl.Fr
+	var M [][]ff.Fr
 
-	M = make([][]gmcl.Fr, n, n)
+	M = make([][]ff.Fr, n, n)
 	for j := uint64(0); j < n; j++ {
-		M[j] = make([]gmcl.Fr, len(a[j]))
+		M[j] = make([]ff.Fr, len(a[j]))
 		copy(M[j], a[j])
 	}
 
-	var x []gmcl.Fr
-	var y []gmcl.Fr
+	var x []ff.Fr
+	var y []ff.Fr
 	for i := uint8(0); i < l; i++ {
 
 		L := uint64(1) << (l - i)
 
-		m := make([][]gmcl.Fr, L/2)
+		m := make([][]ff.Fr, L/2)
 		for j := uint64(0); j < L; j += 2 {
 			x = M[j]
 			y = M[j+1]
-			m[j/2] = PolyMul(x, y)
+			m[j/2] = fs.PolyMul(x, y)
 		}
 		M = m
 	}
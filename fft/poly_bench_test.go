@@ -5,15 +5,14 @@ import (
 	"testing"
 
 	"github.com/accumulators-agg/go-poly/ff"
-	gmcl "github.com/alinush/go-mcl"
 )
 
 func BenchmarkPolyMul(b *testing.B) {
 
 	for scale := uint8(4); scale < 15; scale++ {
 		n := uint64(1) << scale
-		A := make([]gmcl.Fr, n, n)
-		B := make([]gmcl.Fr, n, n)
+		A := make([]ff.Fr, n, n)
+		B := make([]ff.Fr, n, n)
 		for i := uint64(0); i < n; i++ {
 			A[i] = *(ff.RandomFr())
 			B[i] = *(ff.RandomFr())
@@ -31,7 +30,7 @@ func BenchmarkPolyTree(b *testing.B) {
 
 	for scale := uint8(4); scale < 15; scale++ {
 		n := uint64(1) << scale
-		A := make([]gmcl.Fr, n, n)
+		A := make([]ff.Fr, n, n)
 		for i := uint64(0); i < n; i++ {
 			A[i] = *(ff.RandomFr())
 		}
@@ -48,8 +47,8 @@ func BenchmarkPolyDiv(b *testing.B) {
 
 	for scale := uint8(10); scale < 15; scale++ {
 		n := uint64(1) << scale
-		A := make([]gmcl.Fr, n, n)
-		B := make([]gmcl.Fr, 2, 2)
+		A := make([]ff.Fr, n, n)
+		B := make([]ff.Fr, 2, 2)
 		for i := uint64(0); i < n; i++ {
 			A[i] = *(ff.RandomFr())
 		}
@@ -68,7 +67,7 @@ func BenchmarkPolyDifferentiate(b *testing.B) {
 
 	for scale := uint8(10); scale < 15; scale++ {
 		n := uint64(1) << scale
-		data := make([]gmcl.Fr, n, n)
+		data := make([]ff.Fr, n, n)
 		for i := uint64(0); i < n; i++ {
 			data[i] = *(ff.RandomFr())
 		}
@@ -85,8 +84,8 @@ func BenchmarkPolyXGCD1Balanced(b *testing.B) {
 
 	for scale := uint8(10); scale < 13; scale++ {
 		n := uint64(1) << scale
-		A := make([]gmcl.Fr, n, n)
-		B := make([]gmcl.Fr, n, n)
+		A := make([]ff.Fr, n, n)
+		B := make([]ff.Fr, n, n)
 		for i := uint64(0); i < n; i++ {
 			A[i] = *(ff.RandomFr())
 			B[i] = *(ff.RandomFr())
@@ -104,8 +103,8 @@ func BenchmarkPolyXGCD2Balanced(b *testing.B) {
 
 	for scale := uint8(10); scale < 13; scale++ {
 		n := uint64(1) << scale
-		A := make([]gmcl.Fr, n, n)
-		B := make([]gmcl.Fr, n, n)
+		A := make([]ff.Fr, n, n)
+		B := make([]ff.Fr, n, n)
 		for i := uint64(0); i < n; i++ {
 			A[i] = *(ff.RandomFr())
 			B[i] = *(ff.RandomFr())
@@ -122,8 +121,8 @@ func BenchmarkPolyXGCD1Lopsided(b *testing.B) {
 
 	for scale := uint8(10); scale < 13; scale++ {
 		n := uint64(1) << scale
-		A := make([]gmcl.Fr, n, n)
-		B := make([]gmcl.Fr, 2, 2)
+		A := make([]ff.Fr, n, n)
+		B := make([]ff.Fr, 2, 2)
 		for i := uint64(0); i < n; i++ {
 			A[i] = *(ff.RandomFr())
 		}
@@ -142,8 +141,8 @@ func BenchmarkPolyXGCD2Lopsided(b *testing.B) {
 
 	for scale := uint8(10); scale < 13; scale++ {
 		n := uint64(1) << scale
-		A := make([]gmcl.Fr, n, n)
-		B := make([]gmcl.Fr, 2, 2)
+		A := make([]ff.Fr, n, n)
+		B := make([]ff.Fr, 2, 2)
 		for i := uint64(0); i < n; i++ {
 			A[i] = *(ff.RandomFr())
 		}
@@ -162,7 +161,7 @@ func BenchmarkPolySubProductTree(b *testing.B) {
 
 	for scale := uint8(10); scale < 15; scale++ {
 		n := uint64(1) << scale
-		A := make([]gmcl.Fr, n, n)
+		A := make([]ff.Fr, n, n)
 		for i := uint64(0); i < n; i++ {
 			A[i] = *(ff.RandomFr())
 		}
@@ -179,7 +178,7 @@ func BenchmarkPolyMultiEvaluate(b *testing.B) {
 
 	for scale := uint8(10); scale < 15; scale++ {
 		n := uint64(1) << scale
-		A := make([]gmcl.Fr, n, n)
+		A := make([]ff.Fr, n, n)
 		for i := uint64(0); i < n; i++ {
 			A[i] = *(ff.RandomFr())
 		}
@@ -192,3 +191,149 @@ func BenchmarkPolyMultiEvaluate(b *testing.B) {
 		})
 	}
 }
+
+func BenchmarkPolyDivNewton(b *testing.B) {
+
+	for scale := uint8(10); scale < 15; scale++ {
+		n := uint64(1) << scale
+		A := make([]ff.Fr, n, n)
+		B := make([]ff.Fr, n/2, n/2)
+		for i := uint64(0); i < n; i++ {
+			A[i] = *(ff.RandomFr())
+		}
+		for i := uint64(0); i < n/2; i++ {
+			B[i] = *(ff.RandomFr())
+		}
+		b.Run(fmt.Sprintf("scale_%d", scale), func(t *testing.B) {
+			t.ResetTimer()
+			for i := 0; i < t.N; i++ {
+				_, _ = PolyDivNewton(A, B)
+			}
+		})
+	}
+}
+
+func BenchmarkPolyXGCDHalfBalanced(b *testing.B) {
+
+	for scale := uint8(10); scale < 13; scale++ {
+		n := uint64(1) << scale
+		A := make([]ff.Fr, n, n)
+		B := make([]ff.Fr, n, n)
+		for i := uint64(0); i < n; i++ {
+			A[i] = *(ff.RandomFr())
+			B[i] = *(ff.RandomFr())
+		}
+		b.Run(fmt.Sprintf("scale_%d", scale), func(t *testing.B) {
+			t.ResetTimer()
+			for i := 0; i < t.N; i++ {
+				_, _, _ = xGCDHalf(A, B)
+			}
+		})
+	}
+}
+
+func BenchmarkPolyInterpolate(b *testing.B) {
+
+	for scale := uint8(10); scale < 15; scale++ {
+		n := uint64(1) << scale
+		X := make([]ff.Fr, n, n)
+		Y := make([]ff.Fr, n, n)
+		for i := uint64(0); i < n; i++ {
+			X[i] = *(ff.RandomFr())
+			Y[i] = *(ff.RandomFr())
+		}
+		b.Run(fmt.Sprintf("scale_%d", scale), func(t *testing.B) {
+			t.ResetTimer()
+			for i := 0; i < t.N; i++ {
+				_ = PolyInterpolate(X, Y)
+			}
+		})
+	}
+}
+
+func BenchmarkPolyEvalMany(b *testing.B) {
+
+	for scale := uint8(2); scale < 11; scale++ {
+		n := uint64(1) << scale
+		A := make([]ff.Fr, n, n)
+		X := make([]ff.Fr, n, n)
+		for i := uint64(0); i < n; i++ {
+			A[i] = *(ff.RandomFr())
+			X[i] = *(ff.RandomFr())
+		}
+		b.Run(fmt.Sprintf("scale_%d", scale), func(t *testing.B) {
+			t.ResetTimer()
+			for i := 0; i < t.N; i++ {
+				_ = PolyEvalMany(A, X)
+			}
+		})
+	}
+}
+
+func BenchmarkPolyXGCDUntil1Balanced(b *testing.B) {
+
+	for scale := uint8(8); scale < 11; scale++ {
+		n := uint64(1) << scale
+		A := make([]ff.Fr, n, n)
+		B := make([]ff.Fr, n, n)
+		for i := uint64(0); i < n; i++ {
+			A[i] = *(ff.RandomFr())
+			B[i] = *(ff.RandomFr())
+		}
+		b.Run(fmt.Sprintf("scale_%d", scale), func(t *testing.B) {
+			t.ResetTimer()
+			for i := 0; i < t.N; i++ {
+				_, _, _ = xGCDUntil1(A, B, int(n/2))
+			}
+		})
+	}
+}
+
+func BenchmarkPolyXGCDUntilHalfBalanced(b *testing.B) {
+
+	for scale := uint8(8); scale < 11; scale++ {
+		n := uint64(1) << scale
+		A := make([]ff.Fr, n, n)
+		B := make([]ff.Fr, n, n)
+		for i := uint64(0); i < n; i++ {
+			A[i] = *(ff.RandomFr())
+			B[i] = *(ff.RandomFr())
+		}
+		b.Run(fmt.Sprintf("scale_%d", scale), func(t *testing.B) {
+			t.ResetTimer()
+			for i := 0; i < t.N; i++ {
+				_, _, _ = xGCDUntilHalf(A, B, int(n/2))
+			}
+		})
+	}
+}
+
+func BenchmarkBerlekampMassey(b *testing.B) {
+
+	for scale := uint8(8); scale < 12; scale++ {
+		n := 1 << scale
+		C := append([]ff.Fr{ff.ONE}, randomPoly(n/2)...)
+		seq := linearRecurrence(randomPoly(n/2), C, n)
+		b.Run(fmt.Sprintf("scale_%d", scale), func(t *testing.B) {
+			t.ResetTimer()
+			for i := 0; i < t.N; i++ {
+				_ = BerlekampMassey(seq)
+			}
+		})
+	}
+}
+
+func BenchmarkBerlekampMasseyPade(b *testing.B) {
+
+	for scale := uint8(8); scale < 12; scale++ {
+		n := 1 << scale
+		C := append([]ff.Fr{ff.ONE}, randomPoly(n/2)...)
+		seq := linearRecurrence(randomPoly(n/2), C, n)
+		b.Run(fmt.Sprintf("scale_%d", scale), func(t *testing.B) {
+			t.ResetTimer()
+			for i := 0; i < t.N; i++ {
+				_ = cachedSettings.berlekampMasseyPade(seq)
+			}
+		})
+	}
+}
@@ -0,0 +1,119 @@
+package fft
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/accumulators-agg/go-poly/ff"
+)
+
+// Returns the first n terms of the sequence with the given initial terms and connection polynomial C
+func linearRecurrence(init []ff.Fr, C []ff.Fr, n int) []ff.Fr {
+	seq := make([]ff.Fr, n, n)
+	copy(seq, init)
+	var tmp ff.Fr
+	for i := len(init); i < n; i++ {
+		for j := 1; j < len(C) && j <= i; j++ {
+			ff.FrMul(&tmp, &C[j], &seq[i-j])
+			ff.FrSub(&seq[i], &seq[i], &tmp)
+		}
+	}
+	return seq
+}
+
+// Returns true if C is a connection polynomial of length len(C) - 1 for seq
+func generatesSequence(C []ff.Fr, seq []ff.Fr) bool {
+	if len(C) == 0 || C[0].IsOne() == false {
+		return false
+	}
+	L := len(C) - 1
+	for i := L; i < len(seq); i++ {
+		var d, tmp ff.Fr
+		for j := 0; j <= L; j++ {
+			ff.FrMul(&tmp, &C[j], &seq[i-j])
+			ff.FrAdd(&d, &d, &tmp)
+		}
+		if d.IsZero() == false {
+			return false
+		}
+	}
+	return true
+}
+
+func TestBerlekampMassey(t *testing.T) {
+	var tests = []struct {
+		seq  []int64
+		want []int64
+	}{
+		{[]int64{}, []int64{1}},
+		{[]int64{0, 0, 0}, []int64{1}},
+		{[]int64{5, 5, 5, 5}, []int64{1, -1}},
+		{[]int64{1, 2, 4, 8, 16}, []int64{1, -2}},
+		// Fibonacci: s_i = s_{i-1} + s_{i-2}
+		{[]int64{0, 1, 1, 2, 3, 5, 8, 13}, []int64{1, -1, -1}},
+		// Only the last term is not zero: no recurrence shorter than the sequence
+		{[]int64{0, 0, 0, 1}, []int64{1, 0, 0, 0, -1}},
+		{[]int64{1, 0, 0, 0}, []int64{1, 0}},
+	}
+
+	for counter, tt := range tests {
+		testname := fmt.Sprintf("%d", counter+1)
+		t.Run(testname, func(t *testing.T) {
+			seq := ff.FromInt64Vec(tt.seq)
+			want := ff.FromInt64Vec(tt.want)
+			if got := BerlekampMassey(seq); CheckEqualVec(got, want) == false {
+				t.Errorf("BerlekampMassey: expected %d coefficients, got %d, or they did not match.", len(want), len(got))
+			}
+			if got := cachedSettings.berlekampMasseyPade(seq); len(got) != len(want) || generatesSequence(got, seq) == false {
+				t.Errorf("berlekampMasseyPade: expected a recurrence of length %d, got %d.", len(want)-1, len(got)-1)
+			}
+		})
+	}
+}
+
+func TestBerlekampMasseyRandom(t *testing.T) {
+	fs := NewFFTSettings(12)
+	var tests = []struct {
+		L, n int
+	}{
+		{1, 2},
+		{3, 6},
+		{3, 10},
+		{10, 15},
+		{40, 80},
+		{100, 300},
+		{150, 300},
+		{200, 300},
+		{300, 300},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%d-%d", tt.L, tt.n), func(t *testing.T) {
+			C := append([]ff.Fr{ff.ONE}, randomPoly(tt.L)...)
+			seq := linearRecurrence(randomPoly(tt.L), C, tt.n)
+
+			got := BerlekampMassey(seq)
+			if generatesSequence(got, seq) == false {
+				t.Errorf("BerlekampMassey: Answer does not generate the sequence.")
+			}
+			pade := fs.berlekampMasseyPade(seq)
+			if generatesSequence(pade, seq) == false {
+				t.Errorf("berlekampMasseyPade: Answer does not generate the sequence.")
+			}
+			if len(pade) != len(got) {
+				t.Errorf("berlekampMasseyPade: expected length %d, got %d.", len(got)-1, len(pade)-1)
+			}
+			if 2*tt.L <= tt.n {
+				if CheckEqualVec(got, C) == false {
+					t.Errorf("BerlekampMassey: Answer did not match with the connection polynomial.")
+				}
+				if CheckEqualVec(pade, C) == false {
+					t.Errorf("berlekampMasseyPade: Answer did not match with the connection polynomial.")
+				}
+			}
+			if fast := fs.BerlekampMasseyFast(seq); len(fast) != len(got) || generatesSequence(fast, seq) == false {
+				t.Errorf("BerlekampMasseyFast: Answer does not generate the sequence.")
+			}
+		})
+	}
+}
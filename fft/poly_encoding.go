@@ -0,0 +1,475 @@
+package fft
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/accumulators-agg/go-poly/ff"
+)
+
+// Binary format, all integers are unsigned 64-bit little-endian:
+//
+//	header: magic [4]byte, version uint8, curve uint8
+//	vector: n, then n coefficients of ff.FrBytes little-endian bytes (ff.FrToBytes)
+//	Poly:        "POLY" header, vector
+//	SubProdTree: "SPTR" header, number of levels, then per level the number of nodes and a vector per node
+//
+// The curve is the ff.Curve selected when encoding, decoding under another curve fails.
+// JSON holds the same fields, with coefficients as 0x-prefixed big-endian hex strings.
+
+const polyEncodingVersion = 1
+
+var (
+	polyMagic = [4]byte{'P', 'O', 'L', 'Y'}
+	treeMagic = [4]byte{'S', 'P', 'T', 'R'}
+)
+
+// Subproduct tree as returned by SubProductTree: M[i][j] is the j-th node of level i, leaves first.
+// Named so that it can be encoded, convert with SubProdTree(M) and [][][]ff.Fr(t).
+type SubProdTree [][][]ff.Fr
+
+// Writes the binary encoding to a buffered w, keeping the first error.
+type polyEncoder struct {
+	w   *bufio.Writer
+	n   int64
+	err error
+}
+
+func (e *polyEncoder) write(b []byte) {
+	if e.err != nil {
+		return
+	}
+	n, err := e.w.Write(b)
+	e.n += int64(n)
+	e.err = err
+}
+
+func (e *polyEncoder) header(magic [4]byte) {
+	e.write(magic[:])
+	e.write([]byte{polyEncodingVersion, uint8(ff.CurrentCurve())})
+}
+
+func (e *polyEncoder) uint64(v uint64) {
+	var b [8]byte
+	binary.LittleEndian.PutUint64(b[:], v)
+	e.write(b[:])
+}
+
+func (e *polyEncoder) vector(a []ff.Fr) {
+	e.uint64(uint64(len(a)))
+	for i := range a {
+		v := ff.FrToBytes(&a[i], ff.LittleEndian)
+		e.write(v[:])
+	}
+}
+
+func (e *polyEncoder) flush() error {
+	if e.err == nil {
+		e.err = e.w.Flush()
+	}
+	return e.err
+}
+
+// Reads the binary encoding. Does not buffer, so r is left right after the decoded value.
+type polyDecoder struct {
+	r io.Reader
+	n int64
+}
+
+func (d *polyDecoder) read(b []byte) error {
+	n, err := io.ReadFull(d.r, b)
+	d.n += int64(n)
+	if err == io.EOF {
+		err = io.ErrUnexpectedEOF
+	}
+	return err
+}
+
+func (d *polyDecoder) header(magic [4]byte) error {
+	var b [6]byte
+	if err := d.read(b[:]); err != nil {
+		return err
+	}
+	if !bytes.Equal(b[:4], magic[:]) {
+		return fmt.Errorf("%w: expected magic %q, got %q", ErrInvalidEncoding, magic[:], b[:4])
+	}
+	if b[4] != polyEncodingVersion {
+		return fmt.Errorf("%w: unsupported version %d", ErrInvalidEncoding, b[4])
+	}
+	return checkEncodingCurve(ff.Curve(b[5]))
+}
+
+func checkEncodingCurve(c ff.Curve) error {
+	if c != ff.CurrentCurve() {
+		return fmt.Errorf("%w: encoded for %s, but %s is selected", ErrInvalidEncoding, c, ff.CurrentCurve())
+	}
+	return nil
+}
+
+func (d *polyDecoder) uint64() (uint64, error) {
+	var b [8]byte
+	if err := d.read(b[:]); err != nil {
+		return 0, err
+	}
+	return binary.LittleEndian.Uint64(b[:]), nil
+}
+
+// Lengths come from the input, so allocations grow with the data actually read.
+const polyDecodeChunk = 1 << 12
+
+func (d *polyDecoder) vector() ([]ff.Fr, error) {
+	n, err := d.uint64()
+	if err != nil {
+		return nil, err
+	}
+	a := make([]ff.Fr, 0, minUint64(n, polyDecodeChunk))
+	buf := make([]byte, ff.FrBytes*minUint64(n, polyDecodeChunk))
+	for remaining := n; remaining > 0; {
+		k := minUint64(remaining, polyDecodeChunk)
+		if err := d.read(buf[:ff.FrBytes*k]); err != nil {
+			return nil, err
+		}
+		for i := uint64(0); i < k; i++ {
+			var x ff.Fr
+			if err := ff.FrFromBytes(&x, buf[ff.FrBytes*i:ff.FrBytes*(i+1)], ff.LittleEndian); err != nil {
+				return nil, fmt.Errorf("%w: coefficient %d: %v", ErrInvalidEncoding, uint64(len(a)), err)
+			}
+			a = append(a, x)
+		}
+		remaining -= k
+	}
+	return a, nil
+}
+
+func minUint64(a, b uint64) uint64 {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// Writes the binary encoding of p to w. Implements io.WriterTo.
+func (p Poly) WriteTo(w io.Writer) (int64, error) {
+	e := polyEncoder{w: bufio.NewWriter(w)}
+	e.header(polyMagic)
+	e.vector(p.coeffs)
+	err := e.flush()
+	return e.n, err
+}
+
+// Reads a polynomial written by Poly.WriteTo, and nothing past it.
+// Returns ErrInvalidEncoding if the data is malformed and io.ErrUnexpectedEOF if it is truncated.
+func ReadPoly(r io.Reader) (Poly, error) {
+	d := polyDecoder{r: r}
+	if err := d.header(polyMagic); err != nil {
+		return Poly{}, fmt.Errorf("ReadPoly: %w", err)
+	}
+	a, err := d.vector()
+	if err != nil {
+		return Poly{}, fmt.Errorf("ReadPoly: %w", err)
+	}
+	return wrapPoly(a), nil
+}
+
+// Implements encoding.BinaryMarshaler.
+func (p Poly) MarshalBinary() ([]byte, error) {
+	var buf bytes.Buffer
+	if _, err := p.WriteTo(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Implements encoding.BinaryUnmarshaler, data must hold exactly one polynomial.
+func (p *Poly) UnmarshalBinary(data []byte) error {
+	r := bytes.NewReader(data)
+	q, err := ReadPoly(r)
+	if err != nil {
+		return err
+	}
+	if r.Len() != 0 {
+		return fmt.Errorf("ReadPoly: %w: %d trailing bytes", ErrInvalidEncoding, r.Len())
+	}
+	*p = q
+	return nil
+}
+
+// Writes a subproduct tree one level at a time, for trees too large to hold twice in memory.
+type SubProdTreeWriter struct {
+	e      polyEncoder
+	levels int
+}
+
+// Writes the header of a tree with the given number of levels to w.
+// Levels follow with WriteLevel, leaves first, and Close completes the encoding.
+func NewSubProdTreeWriter(w io.Writer, levels int) (*SubProdTreeWriter, error) {
+	tw := &SubProdTreeWriter{e: polyEncoder{w: bufio.NewWriter(w)}, levels: levels}
+	tw.e.header(treeMagic)
+	tw.e.uint64(uint64(levels))
+	return tw, tw.e.err
+}
+
+// Writes the next level of the tree.
+func (tw *SubProdTreeWriter) WriteLevel(level [][]ff.Fr) error {
+	if tw.levels == 0 {
+		return fmt.Errorf("SubProdTreeWriter: all levels are already written")
+	}
+	tw.levels--
+	tw.e.uint64(uint64(len(level)))
+	for _, node := range level {
+		tw.e.vector(node)
+	}
+	return tw.e.err
+}
+
+// Flushes the encoding, fails if fewer levels were written than announced.
+// Does not close the underlying writer.
+func (tw *SubProdTreeWriter) Close() error {
+	if err := tw.e.flush(); err != nil {
+		return err
+	}
+	if tw.levels != 0 {
+		return fmt.Errorf("SubProdTreeWriter: %d levels are missing", tw.levels)
+	}
+	return nil
+}
+
+// Returns the number of bytes written so far.
+func (tw *SubProdTreeWriter) Written() int64 {
+	return tw.e.n
+}
+
+// Reads a subproduct tree one level at a time, for trees too large to hold in memory.
+type SubProdTreeReader struct {
+	d      polyDecoder
+	levels int
+	read   int
+}
+
+// Reads the header of a tree written by SubProdTree.WriteTo or SubProdTreeWriter from r.
+// Reading does not buffer, so r is left right after the tree once every level is read.
+func NewSubProdTreeReader(r io.Reader) (*SubProdTreeReader, error) {
+	tr := &SubProdTreeReader{d: polyDecoder{r: r}}
+	if err := tr.d.header(treeMagic); err != nil {
+		return nil, fmt.Errorf("SubProdTreeReader: %w", err)
+	}
+	levels, err := tr.d.uint64()
+	if err != nil {
+		return nil, fmt.Errorf("SubProdTreeReader: %w", err)
+	}
+	if levels > 64 {
+		return nil, fmt.Errorf("SubProdTreeReader: %w: %d levels", ErrInvalidEncoding, levels)
+	}
+	tr.levels = int(levels)
+	return tr, nil
+}
+
+// Returns the number of levels of the tree.
+func (tr *SubProdTreeReader) Levels() int {
+	return tr.levels
+}
+
+// Returns the next level, leaves first, or io.EOF once every level is read.
+func (tr *SubProdTreeReader) Next() ([][]ff.Fr, error) {
+	if tr.read == tr.levels {
+		return nil, io.EOF
+	}
+	n, err := tr.d.uint64()
+	if err != nil {
+		return nil, fmt.Errorf("SubProdTreeReader: level %d: %w", tr.read, err)
+	}
+	level := make([][]ff.Fr, 0, minUint64(n, polyDecodeChunk))
+	for j := uint64(0); j < n; j++ {
+		node, err := tr.d.vector()
+		if err != nil {
+			return nil, fmt.Errorf("SubProdTreeReader: level %d, node %d: %w", tr.read, j, err)
+		}
+		level = append(level, node)
+	}
+	tr.read++
+	return level, nil
+}
+
+// Writes the binary encoding of t to w. Implements io.WriterTo.
+func (t SubProdTree) WriteTo(w io.Writer) (int64, error) {
+	tw, err := NewSubProdTreeWriter(w, len(t))
+	for i := 0; err == nil && i < len(t); i++ {
+		err = tw.WriteLevel(t[i])
+	}
+	if err == nil {
+		err = tw.Close()
+	}
+	return tw.Written(), err
+}
+
+// Reads a whole tree written by SubProdTree.WriteTo or SubProdTreeWriter, and nothing past it.
+// Returns ErrInvalidEncoding if the data is malformed and io.ErrUnexpectedEOF if it is truncated.
+func ReadSubProdTree(r io.Reader) (SubProdTree, error) {
+	tr, err := NewSubProdTreeReader(r)
+	if err != nil {
+		return nil, err
+	}
+	t := make(SubProdTree, 0, tr.Levels())
+	for {
+		level, err := tr.Next()
+		if err == io.EOF {
+			return t, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		t = append(t, level)
+	}
+}
+
+// Implements encoding.BinaryMarshaler.
+func (t SubProdTree) MarshalBinary() ([]byte, error) {
+	var buf bytes.Buffer
+	if _, err := t.WriteTo(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Implements encoding.BinaryUnmarshaler, data must hold exactly one tree.
+func (t *SubProdTree) UnmarshalBinary(data []byte) error {
+	r := bytes.NewReader(data)
+	u, err := ReadSubProdTree(r)
+	if err != nil {
+		return err
+	}
+	if r.Len() != 0 {
+		return fmt.Errorf("SubProdTreeReader: %w: %d trailing bytes", ErrInvalidEncoding, r.Len())
+	}
+	*t = u
+	return nil
+}
+
+// Coefficient in JSON, 0x-prefixed big-endian hex
+type jsonFr ff.Fr
+
+func (x jsonFr) MarshalJSON() ([]byte, error) {
+	v := ff.FrToBytes((*ff.Fr)(&x), ff.BigEndian)
+	return json.Marshal("0x" + hex.EncodeToString(v[:]))
+}
+
+// Accepts up to 64 hex digits, leading zeros may be omitted.
+func (x *jsonFr) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	if !strings.HasPrefix(s, "0x") || len(s) == 2 || len(s) > 2+2*ff.FrBytes {
+		return fmt.Errorf("%w: expected a 0x-prefixed hex coefficient, got %q", ErrInvalidEncoding, s)
+	}
+	s = strings.Repeat("0", 2+2*ff.FrBytes-len(s)) + s[2:]
+	v, err := hex.DecodeString(s)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidEncoding, err)
+	}
+	if err := ff.FrFromBytes((*ff.Fr)(x), v, ff.BigEndian); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidEncoding, err)
+	}
+	return nil
+}
+
+func toJSONVec(a []ff.Fr) []jsonFr {
+	out := make([]jsonFr, len(a))
+	for i := range a {
+		out[i] = jsonFr(a[i])
+	}
+	return out
+}
+
+func fromJSONVec(a []jsonFr) []ff.Fr {
+	out := make([]ff.Fr, len(a))
+	for i := range a {
+		out[i] = ff.Fr(a[i])
+	}
+	return out
+}
+
+type jsonHeader struct {
+	Version int    `json:"version"`
+	Curve   string `json:"curve"`
+}
+
+func newJSONHeader() jsonHeader {
+	return jsonHeader{Version: polyEncodingVersion, Curve: ff.CurrentCurve().String()}
+}
+
+func (h jsonHeader) check() error {
+	if h.Version != polyEncodingVersion {
+		return fmt.Errorf("%w: unsupported version %d", ErrInvalidEncoding, h.Version)
+	}
+	if h.Curve != ff.CurrentCurve().String() {
+		return fmt.Errorf("%w: encoded for %s, but %s is selected", ErrInvalidEncoding, h.Curve, ff.CurrentCurve())
+	}
+	return nil
+}
+
+type jsonPoly struct {
+	jsonHeader
+	Coeffs []jsonFr `json:"coeffs"`
+}
+
+// Implements json.Marshaler: {"version": 1, "curve": "BLS12-381", "coeffs": ["0x...", ...]}, lowest degree first.
+func (p Poly) MarshalJSON() ([]byte, error) {
+	return json.Marshal(jsonPoly{newJSONHeader(), toJSONVec(p.coeffs)})
+}
+
+// Implements json.Unmarshaler.
+func (p *Poly) UnmarshalJSON(data []byte) error {
+	var j jsonPoly
+	if err := json.Unmarshal(data, &j); err != nil {
+		return fmt.Errorf("Poly: %w", err)
+	}
+	if err := j.check(); err != nil {
+		return fmt.Errorf("Poly: %w", err)
+	}
+	*p = wrapPoly(fromJSONVec(j.Coeffs))
+	return nil
+}
+
+type jsonSubProdTree struct {
+	jsonHeader
+	Levels [][][]jsonFr `json:"levels"`
+}
+
+// Implements json.Marshaler: {"version": 1, "curve": "BLS12-381", "levels": [[["0x...", ...], ...], ...]}, leaves first.
+func (t SubProdTree) MarshalJSON() ([]byte, error) {
+	j := jsonSubProdTree{newJSONHeader(), make([][][]jsonFr, len(t))}
+	for i := range t {
+		j.Levels[i] = make([][]jsonFr, len(t[i]))
+		for k := range t[i] {
+			j.Levels[i][k] = toJSONVec(t[i][k])
+		}
+	}
+	return json.Marshal(j)
+}
+
+// Implements json.Unmarshaler.
+func (t *SubProdTree) UnmarshalJSON(data []byte) error {
+	var j jsonSubProdTree
+	if err := json.Unmarshal(data, &j); err != nil {
+		return fmt.Errorf("SubProdTree: %w", err)
+	}
+	if err := j.check(); err != nil {
+		return fmt.Errorf("SubProdTree: %w", err)
+	}
+	u := make(SubProdTree, len(j.Levels))
+	for i := range j.Levels {
+		u[i] = make([][]ff.Fr, len(j.Levels[i]))
+		for k := range j.Levels[i] {
+			u[i][k] = fromJSONVec(j.Levels[i][k])
+		}
+	}
+	*t = u
+	return nil
+}
@@ -0,0 +1,241 @@
+package fft
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/accumulators-agg/go-poly/ff"
+)
+
+func checkEqualTree(a [][][]ff.Fr, b [][][]ff.Fr) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if len(a[i]) != len(b[i]) {
+			return false
+		}
+		for j := range a[i] {
+			if len(a[i][j]) != len(b[i][j]) || (len(a[i][j]) > 0 && CheckEqualVec(a[i][j], b[i][j]) == false) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func TestPolyEncoding(t *testing.T) {
+	for counter, p := range []Poly{{}, polyFromInt64([]int64{7}), polyFromInt64([]int64{1, -2, 3}), NewPoly(randomPoly(5000))} {
+		testname := fmt.Sprintf("%d", counter+1)
+		t.Run(testname, func(t *testing.T) {
+			data, err := p.MarshalBinary()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want := 6 + 8 + ff.FrBytes*(p.Degree()+1); len(data) != want {
+				t.Errorf("Poly.MarshalBinary: expected %d bytes, got %d", want, len(data))
+			}
+			var q Poly
+			if err := q.UnmarshalBinary(data); err != nil {
+				t.Fatal(err)
+			}
+			if q.Equal(p) == false {
+				t.Errorf("Poly.UnmarshalBinary: roundtrip did not match")
+			}
+
+			js, err := json.Marshal(p)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var r Poly
+			if err := json.Unmarshal(js, &r); err != nil {
+				t.Fatal(err)
+			}
+			if r.Equal(p) == false {
+				t.Errorf("Poly.UnmarshalJSON: roundtrip did not match")
+			}
+		})
+	}
+
+	js, _ := json.Marshal(polyFromInt64([]int64{1, -1}))
+	want := `{"version":1,"curve":"BLS12-381","coeffs":["0x0000000000000000000000000000000000000000000000000000000000000001","0x73eda753299d7d483339d80809a1d80553bda402fffe5bfeffffffff00000000"]}`
+	if string(js) != want {
+		t.Errorf("Poly.MarshalJSON: expected %s, got %s", want, js)
+	}
+}
+
+func TestSubProdTreeEncoding(t *testing.T) {
+	for _, n := range []int{1, 4, 64} {
+		t.Run(fmt.Sprintf("%d", n), func(t *testing.T) {
+			tree := SubProdTree(SubProductTree(randomPoly(n)))
+
+			var buf bytes.Buffer
+			written, err := tree.WriteTo(&buf)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if written != int64(buf.Len()) {
+				t.Errorf("SubProdTree.WriteTo: reported %d bytes but wrote %d", written, buf.Len())
+			}
+			// Trailing data must be left in the reader
+			buf.WriteString("tail")
+			got, err := ReadSubProdTree(&buf)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if checkEqualTree(got, tree) == false {
+				t.Errorf("ReadSubProdTree: roundtrip did not match")
+			}
+			if buf.String() != "tail" {
+				t.Errorf("ReadSubProdTree: read past the end of the tree")
+			}
+
+			data, err := tree.MarshalBinary()
+			if err != nil {
+				t.Fatal(err)
+			}
+			var u SubProdTree
+			if err := u.UnmarshalBinary(data); err != nil || checkEqualTree(u, tree) == false {
+				t.Errorf("SubProdTree.UnmarshalBinary: roundtrip did not match, %v", err)
+			}
+
+			js, err := json.Marshal(tree)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var v SubProdTree
+			if err := json.Unmarshal(js, &v); err != nil || checkEqualTree(v, tree) == false {
+				t.Errorf("SubProdTree.UnmarshalJSON: roundtrip did not match, %v", err)
+			}
+
+			// The decoded tree works like the original one
+			f := randomPoly(n)
+			if CheckEqualVec(PolyMultiEvaluate(f, u), PolyMultiEvaluate(f, tree)) == false {
+				t.Errorf("PolyMultiEvaluate: decoded tree gave other values")
+			}
+		})
+	}
+}
+
+func TestSubProdTreeStreaming(t *testing.T) {
+	tree := SubProductTree(randomPoly(16))
+
+	var buf bytes.Buffer
+	tw, err := NewSubProdTreeWriter(&buf, len(tree))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, level := range tree {
+		if err := tw.WriteLevel(level); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tw.WriteLevel(tree[0]); err == nil {
+		t.Errorf("SubProdTreeWriter: expected an error for an extra level")
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	tr, err := NewSubProdTreeReader(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tr.Levels() != len(tree) {
+		t.Fatalf("SubProdTreeReader: expected %d levels, got %d", len(tree), tr.Levels())
+	}
+	for i := range tree {
+		level, err := tr.Next()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if checkEqualTree([][][]ff.Fr{level}, tree[i:i+1]) == false {
+			t.Errorf("SubProdTreeReader: level %d did not match", i)
+		}
+	}
+	if _, err := tr.Next(); err != io.EOF {
+		t.Errorf("SubProdTreeReader: expected io.EOF after the last level, got %v", err)
+	}
+
+	tw, _ = NewSubProdTreeWriter(io.Discard, 2)
+	tw.WriteLevel(tree[0])
+	if err := tw.Close(); err == nil {
+		t.Errorf("SubProdTreeWriter: expected an error for a missing level")
+	}
+}
+
+func TestPolyEncodingErrors(t *testing.T) {
+	data, _ := polyFromInt64([]int64{1, 2, 3}).MarshalBinary()
+	tree, _ := SubProdTree(SubProductTree(randomPoly(4))).MarshalBinary()
+	modified := func(b []byte, i int, v byte) []byte {
+		c := append([]byte{}, b...)
+		c[i] = v
+		return c
+	}
+	// Makes the last coefficient equal to the modulus
+	nonCanonical := append([]byte{}, data...)
+	r := ff.FrToBytes(&ff.MODULUS_MINUS1, ff.LittleEndian)
+	r[0]++
+	copy(nonCanonical[len(data)-ff.FrBytes:], r[:])
+
+	var tests = []struct {
+		name string
+		run  func() error
+		want error
+	}{
+		{"magic", func() error { var p Poly; return p.UnmarshalBinary(modified(data, 0, 'X')) }, ErrInvalidEncoding},
+		{"version", func() error { var p Poly; return p.UnmarshalBinary(modified(data, 4, 2)) }, ErrInvalidEncoding},
+		{"curve", func() error { var p Poly; return p.UnmarshalBinary(modified(data, 5, uint8(ff.BN254))) }, ErrInvalidEncoding},
+		{"tree-as-poly", func() error { var p Poly; return p.UnmarshalBinary(tree) }, ErrInvalidEncoding},
+		{"non-canonical", func() error { var p Poly; return p.UnmarshalBinary(nonCanonical) }, ErrInvalidEncoding},
+		{"trailing", func() error { var p Poly; return p.UnmarshalBinary(append(data, 0)) }, ErrInvalidEncoding},
+		{"truncated", func() error { var p Poly; return p.UnmarshalBinary(data[:len(data)-1]) }, io.ErrUnexpectedEOF},
+		{"huge-length", func() error { var p Poly; return p.UnmarshalBinary(modified(data, 13, 0xff)) }, io.ErrUnexpectedEOF},
+		{"tree-truncated", func() error { var u SubProdTree; return u.UnmarshalBinary(tree[:len(tree)-5]) }, io.ErrUnexpectedEOF},
+		{"tree-levels", func() error { var u SubProdTree; return u.UnmarshalBinary(modified(tree, 6, 65)) }, ErrInvalidEncoding},
+		{"json-curve", func() error {
+			var p Poly
+			return json.Unmarshal([]byte(`{"version":1,"curve":"BN254","coeffs":["0x1"]}`), &p)
+		}, ErrInvalidEncoding},
+		{"json-version", func() error {
+			var p Poly
+			return json.Unmarshal([]byte(`{"version":2,"curve":"BLS12-381","coeffs":["0x1"]}`), &p)
+		}, ErrInvalidEncoding},
+		{"json-prefix", func() error {
+			var p Poly
+			return json.Unmarshal([]byte(`{"version":1,"curve":"BLS12-381","coeffs":["1"]}`), &p)
+		}, ErrInvalidEncoding},
+		{"json-too-long", func() error {
+			var p Poly
+			s := `{"version":1,"curve":"BLS12-381","coeffs":["0x` + strings.Repeat("0", 65) + `"]}`
+			return json.Unmarshal([]byte(s), &p)
+		}, ErrInvalidEncoding},
+		{"json-non-canonical", func() error {
+			var p Poly
+			s := `{"version":1,"curve":"BLS12-381","coeffs":["0x73eda753299d7d483339d80809a1d80553bda402fffe5bfeffffffff00000001"]}`
+			return json.Unmarshal([]byte(s), &p)
+		}, ErrInvalidEncoding},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.run(); errors.Is(err, tt.want) == false {
+				t.Errorf("expected %v, got %v", tt.want, err)
+			}
+		})
+	}
+
+	// Short hex is left padded
+	var p Poly
+	if err := json.Unmarshal([]byte(`{"version":1,"curve":"BLS12-381","coeffs":["0x1","0xff"]}`), &p); err != nil {
+		t.Fatal(err)
+	}
+	if p.Equal(polyFromInt64([]int64{1, 255})) == false {
+		t.Errorf("Poly.UnmarshalJSON: short hex coefficients were not decoded")
+	}
+}
@@ -0,0 +1,91 @@
+package fft
+
+import (
+	"runtime"
+
+	"github.com/accumulators-agg/go-poly/ff"
+)
+
+// Below this many points, evaluating with Horner's rule point by point beats the subproduct tree.
+// PolyMultiEvaluate also switches to it for subtrees with fewer leaves.
+// TODO: tune threshold.
+const polyEvalTreeThreshold = 256
+
+// Below this many multiplications PolyEvalMany stays on the calling goroutine.
+const polyEvalParallelThreshold = 1 << 10
+
+// Computes f(x) using Horner's rule
+// The empty polynomial evaluates to zero.
+func PolyEval(f []ff.Fr, x *ff.Fr) ff.Fr {
+	var y ff.Fr
+	for i := len(f) - 1; i >= 0; i-- {
+		ff.FrMul(&y, &y, x)
+		ff.FrAdd(&y, &y, &f[i])
+	}
+	return y
+}
+
+// Computes f(xs_i) for every point.
+// Fewer points than the subproduct-tree crossover are evaluated with Horner's rule,
+// split across goroutines. More points go through the subproduct tree.
+// xs need not be a power of two.
+func PolyEvalMany(f []ff.Fr, xs []ff.Fr) []ff.Fr {
+	return cachedSettings.PolyEvalMany(f, xs)
+}
+
+// Same as PolyEvalMany, using the roots of unity and concurrency of fs.
+func (fs *FFTSettings) PolyEvalMany(f []ff.Fr, xs []ff.Fr) []ff.Fr {
+	n := len(xs)
+	if n == 0 {
+		return []ff.Fr{}
+	}
+	if n >= polyEvalTreeThreshold && len(f) > 0 {
+		M := fs.subProductTree(xs)
+		if len(f) > len(M[0]) {
+			// f mod M(x) takes the same values at xs and fits the tree.
+			_, f = fs.PolyDiv(f, M[len(M)-1][0])
+		}
+		return fs.PolyMultiEvaluate(f, M)[:n]
+	}
+
+	out := make([]ff.Fr, n, n)
+	workers := fs.concurrency()
+	if len(f)*n < polyEvalParallelThreshold {
+		workers = 1
+	}
+	parallelChunks(uint64(n), workers, func(lo, hi uint64) {
+		for i := lo; i < hi; i++ {
+			out[i] = PolyEval(f, &xs[i])
+		}
+	})
+	return out
+}
+
+// Returns the number of goroutines a single routine may use, GOMAXPROCS for the nil settings.
+func (fs *FFTSettings) concurrency() int {
+	if fs == nil {
+		return runtime.GOMAXPROCS(0)
+	}
+	return fs.Concurrency
+}
+
+// Evaluates f at the leaves of a subproduct tree directly.
+// Leaves of the form (x - a) are evaluated at a with Horner's rule, any other leaf,
+// such as the constant (1) padding, by the remainder of the division.
+func (fs *FFTSettings) polyEvalLeaves(f []ff.Fr, leaves [][]ff.Fr) ([]ff.Fr, error) {
+	out := make([]ff.Fr, len(leaves), len(leaves))
+	var x ff.Fr
+	for i, leaf := range leaves {
+		if len(leaf) == 2 && leaf[1].IsOne() {
+			ff.FrNeg(&x, &leaf[0])
+			out[i] = PolyEval(f, &x)
+			continue
+		}
+		r, err := fs.polyRemainder(f, leaf)
+		if err != nil {
+			return nil, err
+		}
+		ff.CopyFr(&out[i], &r[0])
+	}
+	return out, nil
+}
@@ -0,0 +1,64 @@
+package fft
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/accumulators-agg/go-poly/ff"
+)
+
+func TestPolyEval(t *testing.T) {
+
+	var tests = []struct {
+		f    []int64
+		x    int64
+		want int64
+	}{
+		{[]int64{}, 5, 0},
+		{[]int64{7}, 5, 7},
+		{[]int64{1, 2, 3, 4}, 0, 1},
+		{[]int64{1, 2, 3, 4}, 2, 49},
+		{[]int64{1, 2, 3, 4}, -1, -2},
+		{[]int64{-5, 0, 0, 1, 0}, 3, 22},
+	}
+
+	for counter, tt := range tests {
+		testname := fmt.Sprintf("%d", counter+1)
+		t.Run(testname, func(t *testing.T) {
+			fFr := ff.FromInt64Vec(tt.f)
+			xFr := ff.FromInt64Vec([]int64{tt.x})
+			wantFr := ff.FromInt64Vec([]int64{tt.want})
+			got := PolyEval(fFr, &xFr[0])
+			if got.IsEqual(&wantFr[0]) == false {
+				t.Errorf("PolyEval: Answer did not match with expected.")
+			}
+		})
+	}
+}
+
+func TestPolyEvalMany(t *testing.T) {
+	sequential := NewFFTSettings(10)
+	sequential.Concurrency = 1
+
+	for _, sizes := range [][2]int{{1, 0}, {1, 3}, {40, 5}, {100, 31}, {64, 32}, {20, 100}, {300, 257}} {
+		testname := fmt.Sprintf("%d-%d", sizes[0], sizes[1])
+		t.Run(testname, func(t *testing.T) {
+			f := randomPoly(sizes[0])
+			xs := randomPoly(sizes[1])
+
+			got := PolyEvalMany(f, xs)
+			if len(got) != len(xs) {
+				t.Fatalf("PolyEvalMany: expected %d values, got %d", len(xs), len(got))
+			}
+			for i := range xs {
+				want := PolyEval(f, &xs[i])
+				if got[i].IsEqual(&want) == false {
+					t.Fatalf("PolyEvalMany: Answer did not match with PolyEval at %d.", i)
+				}
+			}
+			if len(xs) > 0 && CheckEqualVec(sequential.PolyEvalMany(f, xs), got) == false {
+				t.Errorf("PolyEvalMany: Sequential answer did not match with parallel.")
+			}
+		})
+	}
+}
@@ -0,0 +1,195 @@
+package fft
+
+import (
+	"github.com/accumulators-agg/go-poly/ff"
+)
+
+// Below this degree the half-GCD recursion falls back to plain Euclidean steps.
+// TODO: tune threshold.
+const hgcdThreshold = 32
+
+// Inputs with at least this many coefficients use the half-GCD in XGCD.
+const xgcdHalfThreshold = 128
+
+// 2x2 matrix of polynomials, used to accumulate the quotients of the remainder sequence.
+// [[M00, M01], [M10, M11]]
+type polyMatrix [2][2][]ff.Fr
+
+func polyMatrixIdentity() polyMatrix {
+	return polyMatrix{
+		{[]ff.Fr{ff.ONE}, []ff.Fr{ff.ZERO}},
+		{[]ff.Fr{ff.ZERO}, []ff.Fr{ff.ONE}},
+	}
+}
+
+// Matrix of a single Euclidean step: (a, b) -> (b, a - q * b)
+func polyMatrixQuotient(q []ff.Fr) polyMatrix {
+	return polyMatrix{
+		{[]ff.Fr{ff.ZERO}, []ff.Fr{ff.ONE}},
+		{[]ff.Fr{ff.ONE}, PolySub([]ff.Fr{ff.ZERO}, q)},
+	}
+}
+
+// Computes A * B
+func (fs *FFTSettings) polyMatrixMul(A polyMatrix, B polyMatrix) polyMatrix {
+	var C polyMatrix
+	for i := 0; i < 2; i++ {
+		for j := 0; j < 2; j++ {
+			C[i][j] = PolyAdd(fs.PolyMul(A[i][0], B[0][j]), fs.PolyMul(A[i][1], B[1][j]))
+		}
+	}
+	return C
+}
+
+// Computes (c, d) = M * (a, b)
+func (fs *FFTSettings) polyMatrixApply(M polyMatrix, a []ff.Fr, b []ff.Fr) ([]ff.Fr, []ff.Fr) {
+	c := PolyAdd(fs.PolyMul(M[0][0], a), fs.PolyMul(M[0][1], b))
+	d := PolyAdd(fs.PolyMul(M[1][0], a), fs.PolyMul(M[1][1], b))
+	return c, d
+}
+
+// Returns the degree of A, or -1 for the zero polynomial.
+func polyDegree(a []ff.Fr) int {
+	a = PolyCondense(a)
+	if len(a) == 1 && a[0].IsZero() {
+		return -1
+	}
+	return len(a) - 1
+}
+
+// Returns A div x^k
+func polyShiftRight(a []ff.Fr, k int) []ff.Fr {
+	if k >= len(a) {
+		return []ff.Fr{ff.ZERO}
+	}
+	return PolyCondense(a[k:])
+}
+
+// Runs Euclidean steps on (a, b) until the remainder degree drops below m.
+// Returns the matrix M s.t. M * (a, b) = (r_j, r_{j+1}) with deg(r_j) >= m > deg(r_{j+1}).
+func (fs *FFTSettings) hgcdEuclid(a []ff.Fr, b []ff.Fr, m int) polyMatrix {
+	M := polyMatrixIdentity()
+	for polyDegree(b) >= m {
+		q, r := fs.PolyDiv(a, b)
+		a, b = b, r
+		M = fs.polyMatrixMul(polyMatrixQuotient(q), M)
+	}
+	return M
+}
+
+// Half-GCD (Knuth–Schönhage, in the formulation of Thull and Yap).
+// Requires deg(a) > deg(b).
+// Returns the matrix M s.t. M * (a, b) = (r_j, r_{j+1}) are the consecutive remainders
+// of the Euclidean remainder sequence of (a, b) with deg(r_j) >= ceil(deg(a) / 2) > deg(r_{j+1}).
+func (fs *FFTSettings) hgcd(a []ff.Fr, b []ff.Fr) polyMatrix {
+	n := polyDegree(a)
+	m := (n + 1) / 2
+	if polyDegree(b) < m {
+		return polyMatrixIdentity()
+	}
+	if n < hgcdThreshold {
+		return fs.hgcdEuclid(a, b, m)
+	}
+
+	// The quotients of the top halves agree with those of (a, b)
+	R := fs.hgcd(polyShiftRight(a, m), polyShiftRight(b, m))
+	c, d := fs.polyMatrixApply(R, a, b)
+	if polyDegree(d) < m {
+		return R
+	}
+
+	q, e := fs.PolyDiv(c, d)
+	R = fs.polyMatrixMul(polyMatrixQuotient(q), R)
+
+	k := 2*m - polyDegree(d)
+	S := fs.hgcd(polyShiftRight(d, k), polyShiftRight(e, k))
+	return fs.polyMatrixMul(S, R)
+}
+
+// Computes Extended GCD using the half-GCD in O(M(n) log n)
+// Returns the same (g, u, v) as xGCD1 and xGCD2.
+// a * u + b * v = g
+func xGCDHalf(a []ff.Fr, b []ff.Fr) (g []ff.Fr, u []ff.Fr, v []ff.Fr) {
+	return cachedSettings.xGCDHalf(a, b)
+}
+
+// Same as xGCDHalf, using the roots of unity of fs.
+func (fs *FFTSettings) xGCDHalf(a []ff.Fr, b []ff.Fr) (g []ff.Fr, u []ff.Fr, v []ff.Fr) {
+
+	if len(b) > len(a) {
+		g, v, u := fs.xGCDHalf(b, a)
+		return g, u, v
+	}
+
+	r0 := PolyCondense(a)
+	r1 := PolyCondense(b)
+	M := polyMatrixIdentity()
+
+	for IsPolyZero(r1) == false {
+		if polyDegree(r0) > polyDegree(r1) {
+			R := fs.hgcd(r0, r1)
+			r0, r1 = fs.polyMatrixApply(R, r0, r1)
+			M = fs.polyMatrixMul(R, M)
+			if IsPolyZero(r1) {
+				break
+			}
+		}
+		q, r := fs.PolyDiv(r0, r1)
+		r0, r1 = r1, r
+		M = fs.polyMatrixMul(polyMatrixQuotient(q), M)
+	}
+	return r0, M[0][0], M[0][1]
+}
+
+// Computes XGCDUntil using the half-GCD: each hgcd call runs on the top coefficients
+// shifted s.t. it stops at remainder degree about degBound, Euclidean steps do the rest.
+// Returns the same (r, u, v) as xGCDUntil1.
+// a * u + b * v = r
+func xGCDUntilHalf(a []ff.Fr, b []ff.Fr, degBound int) (r []ff.Fr, u []ff.Fr, v []ff.Fr) {
+	_, r, M := cachedSettings.xGCDUntilHalf(a, b, degBound)
+	return r, M[1][0], M[1][1]
+}
+
+// Same as xGCDUntilHalf, using the roots of unity of fs. Returns the state of xGCDUntilState.
+func (fs *FFTSettings) xGCDUntilHalf(a []ff.Fr, b []ff.Fr, degBound int) (r0 []ff.Fr, r1 []ff.Fr, M polyMatrix) {
+
+	a, b = PolyCondense(a), PolyCondense(b)
+	if len(b) > len(a) {
+		r0, r1, M = fs.xGCDUntilHalf(b, a, degBound)
+		M[0][0], M[0][1] = M[0][1], M[0][0]
+		M[1][0], M[1][1] = M[1][1], M[1][0]
+		return r0, r1, M
+	}
+
+	r0, r1 = a, b
+	M = polyMatrixIdentity()
+	if polyDegree(r0) < degBound {
+		// a is the remainder, b comes before it
+		return r1, r0, polyMatrix{M[1], M[0]}
+	}
+
+	for polyDegree(r1) >= degBound {
+		if n := polyDegree(r0); n > polyDegree(r1) {
+			// hgcd of (r0, r1) div x^k stops at the first remainder of degree < (n - k) / 2 + k = degBound
+			k := 2*degBound - n
+			if k < 0 {
+				k = 0
+			}
+			R := fs.hgcd(polyShiftRight(r0, k), polyShiftRight(r1, k))
+			r0, r1 = fs.polyMatrixApply(R, r0, r1)
+			M = fs.polyMatrixMul(R, M)
+			if polyDegree(r1) < degBound {
+				break
+			}
+		}
+		q, r := fs.PolyDiv(r0, r1)
+		r0, r1 = r1, r
+		M = fs.polyMatrixMul(polyMatrixQuotient(q), M)
+	}
+	for i := range M {
+		for j := range M[i] {
+			M[i][j] = PolyCondense(M[i][j])
+		}
+	}
+	return r0, PolyCondense(r1), M
+}
@@ -0,0 +1,145 @@
+package fft
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/accumulators-agg/go-poly/ff"
+)
+
+func randomPoly(n int) []ff.Fr {
+	a := make([]ff.Fr, n, n)
+	for i := 0; i < n; i++ {
+		a[i] = *ff.RandomFr()
+	}
+	return a
+}
+
+func checkEqualPolyMatrix(A polyMatrix, B polyMatrix) bool {
+	flag := true
+	for i := 0; i < 2; i++ {
+		for j := 0; j < 2; j++ {
+			flag = flag && CheckEqualVec(A[i][j], B[i][j])
+		}
+	}
+	return flag
+}
+
+func TestPolyHGCD(t *testing.T) {
+	fs := NewFFTSettings(12)
+	for _, sizes := range [][2]int{{8, 5}, {40, 39}, {64, 40}, {101, 100}, {150, 77}, {257, 256}} {
+		testname := fmt.Sprintf("%d-%d", sizes[0], sizes[1])
+		t.Run(testname, func(t *testing.T) {
+			a := randomPoly(sizes[0])
+			b := randomPoly(sizes[1])
+			m := (polyDegree(a) + 1) / 2
+
+			M := fs.hgcd(a, b)
+			if checkEqualPolyMatrix(M, fs.hgcdEuclid(a, b, m)) == false {
+				t.Fatalf("hgcd: Matrix did not match with hgcdEuclid.")
+			}
+			c, d := fs.polyMatrixApply(M, a, b)
+			if polyDegree(c) < m || polyDegree(d) >= m {
+				t.Errorf("hgcd: Got remainder degrees %d, %d around %d.", polyDegree(c), polyDegree(d), m)
+			}
+		})
+	}
+}
+
+func TestPolyXGCDHalf(t *testing.T) {
+	var tests = []struct {
+		aLen, bLen, commonLen int
+	}{
+		{4, 4, 1},
+		{20, 3, 1},
+		{150, 150, 1},
+		{150, 149, 1},
+		{200, 60, 1},
+		{60, 200, 1},
+		{130, 140, 7},
+		{140, 2, 3},
+	}
+
+	for counter, tt := range tests {
+		testname := fmt.Sprintf("%d", counter+1)
+		t.Run(testname, func(t *testing.T) {
+			common := randomPoly(tt.commonLen)
+			aFr := PolyMul(randomPoly(tt.aLen), common)
+			bFr := PolyMul(randomPoly(tt.bLen), common)
+
+			g, u, v := xGCDHalf(aFr, bFr)
+			g1, u1, v1 := xGCD1(aFr, bFr)
+			g2, u2, v2 := xGCD2(aFr, bFr)
+
+			if !CheckEqualVec(g, g1) || !CheckEqualVec(u, u1) || !CheckEqualVec(v, v1) {
+				t.Errorf("xGCDHalf: Answer did not match with xGCD1.")
+			}
+			if !CheckEqualVec(g, g2) || !CheckEqualVec(u, u2) || !CheckEqualVec(v, v2) {
+				t.Errorf("xGCDHalf: Answer did not match with xGCD2.")
+			}
+			if IsPolyEqual(PolyAdd(PolyMul(aFr, u), PolyMul(bFr, v)), g) == false {
+				t.Errorf("xGCDHalf: a * u + b * v did not match with g.")
+			}
+			if len(g) != tt.commonLen {
+				t.Errorf("xGCDHalf: Expected a gcd of degree %d, got %d.", tt.commonLen-1, len(g)-1)
+			}
+
+			gX, uX, vX := XGCD(aFr, bFr)
+			if !CheckEqualVec(g, gX) || !CheckEqualVec(u, uX) || !CheckEqualVec(v, vX) {
+				t.Errorf("XGCD: Answer did not match with xGCDHalf.")
+			}
+		})
+	}
+}
+
+func TestPolyXGCDUntil(t *testing.T) {
+	var tests = []struct {
+		aLen, bLen, commonLen, degBound int
+	}{
+		{4, 4, 1, 2},
+		{20, 3, 1, 10},
+		{20, 3, 1, 1},
+		{10, 8, 1, 12},
+		{150, 150, 1, 75},
+		{150, 149, 1, 0},
+		{200, 60, 1, 130},
+		{60, 200, 1, 30},
+		{257, 256, 1, 200},
+		{257, 256, 1, 129},
+		{300, 299, 1, 50},
+		{130, 140, 7, 10},
+		{130, 140, 7, 0},
+	}
+
+	for counter, tt := range tests {
+		testname := fmt.Sprintf("%d", counter+1)
+		t.Run(testname, func(t *testing.T) {
+			common := randomPoly(tt.commonLen)
+			aFr := PolyMul(randomPoly(tt.aLen), common)
+			bFr := PolyMul(randomPoly(tt.bLen), common)
+
+			r, u, v := xGCDUntilHalf(aFr, bFr, tt.degBound)
+			r1, u1, v1 := xGCDUntil1(aFr, bFr, tt.degBound)
+
+			if !CheckEqualVec(r, r1) || !CheckEqualVec(u, u1) || !CheckEqualVec(v, v1) {
+				t.Errorf("xGCDUntilHalf: Answer did not match with xGCDUntil1.")
+			}
+			if IsPolyEqual(PolyAdd(PolyMul(aFr, u), PolyMul(bFr, v)), r) == false {
+				t.Errorf("xGCDUntilHalf: a * u + b * v did not match with r.")
+			}
+			if polyDegree(r) >= tt.degBound {
+				t.Errorf("xGCDUntilHalf: Got remainder degree %d for bound %d.", polyDegree(r), tt.degBound)
+			}
+
+			rX, uX, vX := XGCDUntil(aFr, bFr, tt.degBound)
+			if !CheckEqualVec(r, rX) || !CheckEqualVec(u, uX) || !CheckEqualVec(v, vX) {
+				t.Errorf("XGCDUntil: Answer did not match with xGCDUntilHalf.")
+			}
+
+			r0, rS, M := cachedSettings.xGCDUntilState(aFr, bFr, tt.degBound)
+			if IsPolyEqual(PolyAdd(PolyMul(aFr, M[0][0]), PolyMul(bFr, M[0][1])), r0) == false || !CheckEqualVec(rS, r) {
+				t.Errorf("xGCDUntilState: M * (a, b) did not match with (r0, r1).")
+			}
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package fft
+
+import (
+	"fmt"
+
+	"github.com/accumulators-agg/go-poly/ff"
+)
+
+// Fast interpolation using subproduct tree
+// Computes f(x) of degree < n s.t. f(xs_i) = ys_i for n distinct points, in O(n log^2 n):
+// f(x) = \sum_i ys_i / M'(xs_i) * M(x) / (x - xs_i), where M(x) = \prod_i (x - xs_i)
+// Need not be a power of two
+func PolyInterpolate(xs []ff.Fr, ys []ff.Fr) []ff.Fr {
+	return cachedSettings.PolyInterpolate(xs, ys)
+}
+
+// Same as PolyInterpolate, using the roots of unity of fs.
+func (fs *FFTSettings) PolyInterpolate(xs []ff.Fr, ys []ff.Fr) []ff.Fr {
+	n := len(xs)
+	if n == 0 {
+		panic("PolyInterpolate: Input is empty")
+	}
+	if n != len(ys) {
+		msg := fmt.Sprintf("PolyInterpolate: Got %d points but %d values", n, len(ys))
+		panic(msg)
+	}
+
+	M := fs.subProductTree(xs)
+	k := len(M) - 1
+
+	// Weights ys_i / M'(xs_i)
+	dM := PolyDifferentiate(M[k][0])
+	evals := fs.PolyMultiEvaluate(dM, M)
+
+	for i := 0; i < n; i++ {
+		if evals[i].IsZero() {
+			panic("PolyInterpolate: Points are not distinct")
+		}
+	}
+	ff.BatchInvertParallel(evals[:n], evals[:n], fs.concurrency())
+
+	level := make([][]ff.Fr, len(M[0]))
+	for i := range level {
+		level[i] = make([]ff.Fr, 1, 1)
+		if i < n {
+			ff.FrMul(&level[i][0], &ys[i], &evals[i])
+		}
+	}
+
+	// Combine bottom-up: f = f_L * M_R + f_R * M_L
+	for i := 1; i <= k; i++ {
+		next := make([][]ff.Fr, len(M[i]))
+		for j := range next {
+			l := fs.PolyMul(level[2*j], M[i-1][2*j+1])
+			r := fs.PolyMul(level[2*j+1], M[i-1][2*j])
+			next[j] = PolyAdd(l, r)
+		}
+		level = next
+	}
+	return PolyCondense(level[0])
+}
@@ -0,0 +1,74 @@
+package fft
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/accumulators-agg/go-poly/ff"
+)
+
+func TestPolyInterpolate(t *testing.T) {
+
+	var tests = []struct {
+		evalPoints  []int64
+		evaluations []int64
+		polynomial  []int64
+	}{
+		{
+			[]int64{1, 2, 3, 4},
+			[]int64{10, 49, 142, 313},
+			[]int64{1, 2, 3, 4},
+		},
+		{
+			[]int64{7},
+			[]int64{5},
+			[]int64{5},
+		},
+		{
+			[]int64{3, -1, 2},
+			[]int64{9, 1, 4},
+			[]int64{0, 0, 1},
+		},
+		{
+			[]int64{1, 2, 3, 4, 5},
+			[]int64{6, 6, 6, 6, 6},
+			[]int64{6},
+		},
+	}
+
+	for counter, tt := range tests {
+		testname := fmt.Sprintf("%d", counter+1)
+		t.Run(testname, func(t *testing.T) {
+
+			evalPointsFr := ff.FromInt64Vec(tt.evalPoints)
+			evaluationsFr := ff.FromInt64Vec(tt.evaluations)
+			polynomialFr := ff.FromInt64Vec(tt.polynomial)
+
+			ansFr := PolyInterpolate(evalPointsFr, evaluationsFr)
+
+			flag := CheckEqualVec(ansFr, polynomialFr)
+			if flag == false {
+				t.Errorf("PolyInterpolate: Answer did not match with expected.")
+			}
+		})
+	}
+
+	for _, n := range []int{2, 3, 8, 13, 64, 100} {
+		testname := fmt.Sprintf("random-%d", n)
+		t.Run(testname, func(t *testing.T) {
+			polynomialFr := randomPoly(n)
+			evalPointsFr := randomPoly(n)
+			evaluationsFr := make([]ff.Fr, n, n)
+			for i := 0; i < n; i++ {
+				evaluationsFr[i] = PolyEval(polynomialFr, &evalPointsFr[i])
+			}
+
+			ansFr := PolyInterpolate(evalPointsFr, evaluationsFr)
+
+			flag := CheckEqualVec(ansFr, PolyCondense(polynomialFr))
+			if flag == false {
+				t.Errorf("PolyInterpolate: Answer did not match with expected.")
+			}
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package fft
+
+import (
+	"github.com/accumulators-agg/go-poly/ff"
+)
+
+// Quotients and divisors with at least this many coefficients are divided
+// with Newton iteration instead of the schoolbook loop.
+// TODO: tune threshold.
+const polyDivNewtonThreshold = 64
+
+// Returns true if PolyDiv should use Newton iteration for a / b.
+func polyDivUseNewton(aLen int, bLen int) bool {
+	return aLen-bLen+1 >= polyDivNewtonThreshold && bLen >= polyDivNewtonThreshold
+}
+
+// Returns the coefficients of A in reverse order: x^deg(A) * A(1/x)
+func polyReverse(a []ff.Fr) []ff.Fr {
+	n := len(a)
+	c := make([]ff.Fr, n, n)
+	for i := 0; i < n; i++ {
+		ff.CopyFr(&c[i], &a[n-1-i])
+	}
+	return c
+}
+
+// Returns A mod x^n, i.e. the first n coefficients of A, padded with zeros if needed.
+func polyTruncate(a []ff.Fr, n int) []ff.Fr {
+	c := make([]ff.Fr, n, n)
+	copy(c, a[:ff.Min(n, len(a))])
+	return c
+}
+
+// Computes g(x) s.t. a(x) * g(x) = 1 mod x^n using Newton iteration:
+// g_{2k} = g_k * (2 - a * g_k) mod x^{2k}
+// a(0) must be non-zero.
+func (fs *FFTSettings) polyInvSeries(a []ff.Fr, n int) []ff.Fr {
+	if a[0].IsZero() {
+		panic("polyInvSeries: Constant term must be non-zero.")
+	}
+
+	g := make([]ff.Fr, 1, 1)
+	ff.FrInv(&g[0], &a[0])
+
+	for k := 1; k < n; {
+		k = ff.Min(k<<1, n)
+		e := polyTruncate(fs.PolyMul(polyTruncate(a, k), g), k)
+		// e = 2 - a * g
+		for i := 0; i < k; i++ {
+			ff.FrNeg(&e[i], &e[i])
+		}
+		ff.FrAdd(&e[0], &e[0], &ff.TWO)
+		g = polyTruncate(fs.PolyMul(g, e), k)
+	}
+	return polyTruncate(g, n)
+}
+
+// Computes q(x) and r(x) s.t. a(x) = q(x) * b(x) + r(x) in O(n log n),
+// using the inverse power series of the reversed divisor:
+// rev(q) = rev(a) * rev(b)^{-1} mod x^{deg(a) - deg(b) + 1}
+// Like PolyDiv, panics if B is zero or longer than A once its leading zeros are dropped.
+func PolyDivNewton(A []ff.Fr, B []ff.Fr) ([]ff.Fr, []ff.Fr) {
+	return cachedSettings.PolyDivNewton(A, B)
+}
+
+// Same as PolyDivNewton, using the roots of unity of fs.
+func (fs *FFTSettings) PolyDivNewton(A []ff.Fr, B []ff.Fr) ([]ff.Fr, []ff.Fr) {
+	if IsPolyZero(B) == true {
+		panic("PolyDivNewton: Cannot divide by zero polynomial.")
+	}
+
+	b := PolyCondense(B)
+	if len(b) > len(A) {
+		panic("PolyDivNewton: Deg(B) should be <= Deg(A)")
+	}
+	a := PolyCondense(A)
+	if len(b) > len(a) {
+		// A has leading zeros, so it is its own remainder
+		return []ff.Fr{ff.ZERO}, polyTruncate(a, len(a))
+	}
+
+	k := len(a) - len(b) + 1
+	inv := fs.polyInvSeries(polyReverse(b), k)
+	revQ := polyTruncate(fs.PolyMul(polyTruncate(polyReverse(a), k), inv), k)
+
+	q := PolyCondense(polyReverse(revQ))
+	r := PolySub(a, fs.PolyMul(q, b))
+	return q, r
+}
@@ -0,0 +1,99 @@
+package fft
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/accumulators-agg/go-poly/ff"
+)
+
+func TestPolyInvSeries(t *testing.T) {
+	for _, n := range []int{1, 2, 3, 7, 8, 33} {
+		testname := fmt.Sprintf("len-%d", n)
+		t.Run(testname, func(t *testing.T) {
+			a := make([]ff.Fr, n, n)
+			for i := 0; i < n; i++ {
+				a[i] = *ff.RandomFr()
+			}
+			g := cachedSettings.polyInvSeries(a, n)
+			if len(g) != n {
+				t.Fatalf("polyInvSeries: expected %d coefficients, got %d", n, len(g))
+			}
+			ag := polyTruncate(PolyMul(a, g), n)
+			want := make([]ff.Fr, n, n)
+			want[0] = ff.ONE
+			if CheckEqualVec(ag, want) == false {
+				t.Errorf("polyInvSeries: a * g != 1 mod x^%d", n)
+			}
+		})
+	}
+}
+
+func TestPolyDivNewton(t *testing.T) {
+
+	var tests = []struct {
+		a, b, qwant, rwant []int64
+	}{
+		{[]int64{1, 2, 3, 4}, []int64{5, 1}, []int64{87, -17, 4}, []int64{-434}},
+		{[]int64{8, 10, -5, 3}, []int64{-3, 2, 1}, []int64{-11, 3}, []int64{-25, 41}},
+		{[]int64{8, 10, -5, 3, 0, 0}, []int64{-3, 2, 1, 0}, []int64{-11, 3}, []int64{-25, 41}},
+		{[]int64{1, 2, 0}, []int64{-3, 2, 1}, []int64{0}, []int64{1, 2}},
+		{[]int64{6, 3}, []int64{3}, []int64{2, 1}, []int64{0}},
+	}
+
+	for counter, tt := range tests {
+		testname := fmt.Sprintf("%d", counter+1)
+		t.Run(testname, func(t *testing.T) {
+
+			aFr := ff.FromInt64Vec(tt.a)
+			bFr := ff.FromInt64Vec(tt.b)
+			qwantFr := ff.FromInt64Vec(tt.qwant)
+			rwantFr := ff.FromInt64Vec(tt.rwant)
+			qFr, rFr := PolyDivNewton(aFr, bFr)
+
+			if CheckEqualVec(qwantFr, qFr) == false {
+				t.Errorf("PolyDivNewton: Quotient did not match with expected.")
+			}
+			if CheckEqualVec(rwantFr, rFr) == false {
+				t.Errorf("PolyDivNewton: Remainder did not match with expected.")
+			}
+		})
+	}
+
+	t.Run("degree", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Errorf("PolyDivNewton: expected a panic for Deg(B) > Deg(A), like PolyDiv")
+			}
+		}()
+		PolyDivNewton(ff.FromInt64Vec([]int64{1, 2}), ff.FromInt64Vec([]int64{-3, 2, 1}))
+	})
+
+	for _, sizes := range [][2]int{{2, 1}, {16, 9}, {100, 50}, {300, 64}, {513, 200}} {
+		testname := fmt.Sprintf("random-%d-%d", sizes[0], sizes[1])
+		t.Run(testname, func(t *testing.T) {
+			aFr := make([]ff.Fr, sizes[0], sizes[0])
+			bFr := make([]ff.Fr, sizes[1], sizes[1])
+			for i := range aFr {
+				aFr[i] = *ff.RandomFr()
+			}
+			for i := range bFr {
+				bFr[i] = *ff.RandomFr()
+			}
+
+			q, r := PolyDivNewton(aFr, bFr)
+			if len(r) >= len(bFr) && IsPolyZero(r) == false {
+				t.Errorf("PolyDivNewton: Deg(r) should be < Deg(b)")
+			}
+			if IsPolyEqual(PolyAdd(PolyMul(q, bFr), r), aFr) == false {
+				t.Errorf("PolyDivNewton: q * b + r did not match with a.")
+			}
+
+			// The dispatching PolyDiv must agree with both paths.
+			q2, r2 := PolyDiv(aFr, bFr)
+			if CheckEqualVec(q, q2) == false || CheckEqualVec(r, r2) == false {
+				t.Errorf("PolyDiv: Answer did not match with PolyDivNewton.")
+			}
+		})
+	}
+}
@@ -6,10 +6,9 @@ import (
 
 	"github.com/accumulators-agg/go-poly/debug"
 	"github.com/accumulators-agg/go-poly/ff"
-	gmcl "github.com/alinush/go-mcl"
 )
 
-func CheckEqualVec(a []gmcl.Fr, b []gmcl.Fr) bool {
+func CheckEqualVec(a []ff.Fr, b []ff.Fr) bool {
 	n := len(a)
 	if n == len(b) && n > 0 {
 		flag := true
@@ -21,7 +20,7 @@ func CheckEqualVec(a []gmcl.Fr, b []gmcl.Fr) bool {
 	return false
 }
 
-func printSubTree(M [][][]gmcl.Fr, printMsg string) {
+func printSubTree(M [][][]ff.Fr, printMsg string) {
 	for i := 0; i < len(M); i++ {
 		for j := 0; j < len(M[i]); j++ {
 			msg := fmt.Sprintf("%s [%d, %d]", printMsg, i, j)
@@ -339,7 +338,7 @@ func TestPolyExtGCD(t *testing.T) {
 			uFr := ff.FromInt64Vec(tt.u)
 			vFr := ff.FromInt64Vec(tt.v)
 
-			var g, u, v []gmcl.Fr
+			var g, u, v []ff.Fr
 			var flag bool
 			flag = true
 
@@ -359,6 +358,14 @@ func TestPolyExtGCD(t *testing.T) {
 			if flag == false {
 				t.Errorf("xGCD2: Answer did not match with expected.")
 			}
+			g, u, v = xGCDHalf(aFr, bFr)
+			flag = flag && CheckEqualVec(gFr, g)
+			flag = flag && CheckEqualVec(uFr, u)
+			flag = flag && CheckEqualVec(vFr, v)
+
+			if flag == false {
+				t.Errorf("xGCDHalf: Answer did not match with expected.")
+			}
 			// debug.DebugFrs("g", g)
 			// debug.DebugFrs("u", u)
 			// debug.DebugFrs("v", v)
@@ -399,11 +406,11 @@ func TestPolySubProdTree(t *testing.T) {
 		t.Run(testname, func(t *testing.T) {
 
 			aFr := ff.FromInt64Vec(tt.a)
-			var wantFr [][][]gmcl.Fr
+			var wantFr [][][]ff.Fr
 
-			wantFr = make([][][]gmcl.Fr, len(tt.want))
+			wantFr = make([][][]ff.Fr, len(tt.want))
 			for i := 0; i < len(wantFr); i++ {
-				wantFr[i] = make([][]gmcl.Fr, len(tt.want[i]))
+				wantFr[i] = make([][]ff.Fr, len(tt.want[i]))
 				for j := 0; j < len(tt.want[i]); j++ {
 					wantFr[i][j] = ff.FromInt64Vec(tt.want[i][j])
 				}
@@ -435,16 +442,16 @@ func TestPolySubProdTree(t *testing.T) {
 		testname := fmt.Sprintf("scale-%d", l)
 		t.Run(testname, func(t *testing.T) {
 			n := 1 << l
-			aFr := make([]gmcl.Fr, n, n)
+			aFr := make([]ff.Fr, n, n)
 			for i := 0; i < n; i++ {
 				aFr[i] = *ff.RandomFr()
 			}
-			var temp gmcl.Fr
-			result := []gmcl.Fr{ff.ONE}
-			tempPoly := make([]gmcl.Fr, 2)
+			var temp ff.Fr
+			result := []ff.Fr{ff.ONE}
+			tempPoly := make([]ff.Fr, 2)
 
 			for i := 0; i < n; i++ {
-				gmcl.FrNeg(&temp, &aFr[i])
+				ff.FrNeg(&temp, &aFr[i])
 				tempPoly[0] = temp
 				tempPoly[1] = ff.ONE
 				result = PolyMul(result, tempPoly)
@@ -500,13 +507,13 @@ func TestPolySplitSubProdTree(t *testing.T) {
 		t.Run(testname, func(t *testing.T) {
 
 			aFr := ff.FromInt64Vec(tt.a)
-			var wantLFr, wantRFr [][][]gmcl.Fr
+			var wantLFr, wantRFr [][][]ff.Fr
 
-			wantLFr = make([][][]gmcl.Fr, len(tt.wantL))
-			wantRFr = make([][][]gmcl.Fr, len(tt.wantR))
+			wantLFr = make([][][]ff.Fr, len(tt.wantL))
+			wantRFr = make([][][]ff.Fr, len(tt.wantR))
 			for i := 0; i < len(wantLFr); i++ {
-				wantLFr[i] = make([][]gmcl.Fr, len(tt.wantL[i]))
-				wantRFr[i] = make([][]gmcl.Fr, len(tt.wantR[i]))
+				wantLFr[i] = make([][]ff.Fr, len(tt.wantL[i]))
+				wantRFr[i] = make([][]ff.Fr, len(tt.wantR[i]))
 				for j := 0; j < len(tt.wantL[i]); j++ {
 					wantLFr[i][j] = ff.FromInt64Vec(tt.wantL[i][j])
 					wantRFr[i][j] = ff.FromInt64Vec(tt.wantR[i][j])
@@ -553,6 +560,21 @@ func TestPolyMultiPointEval(t *testing.T) {
 			[]int64{10, 12, 11, 23, 8, 28, 13, 1},
 			[]int64{532066342, 1951327776, 1050110403, 195846264759, 107702244, 784342707664, 3447624049, 13},
 		},
+		{
+			[]int64{5},
+			[]int64{1, 2, 3, 4},
+			[]int64{5, 5, 5, 5},
+		},
+		{
+			[]int64{1, 1},
+			[]int64{1, 2, 3, 4},
+			[]int64{2, 3, 4, 5},
+		},
+		{
+			[]int64{1, 2, 3},
+			[]int64{3},
+			[]int64{34},
+		},
 	}
 
 	for counter, tt := range tests {
@@ -580,9 +602,9 @@ func TestPolyMultiPointEval(t *testing.T) {
 		t.Run(testname, func(t *testing.T) {
 			n := 1 << l
 
-			aFr := make([]gmcl.Fr, n-1, n-1) // Not n!
-			evalPointsFr := make([]gmcl.Fr, n, n)
-			evaluations := make([]gmcl.Fr, n, n)
+			aFr := make([]ff.Fr, n-1, n-1) // Not n!
+			evalPointsFr := make([]ff.Fr, n, n)
+			evaluations := make([]ff.Fr, n, n)
 
 			for i := 0; i < n; i++ {
 				if i < n-1 {
@@ -594,8 +616,15 @@ func TestPolyMultiPointEval(t *testing.T) {
 			polynomialFr := PolyTree(aFr)
 			M := SubProductTree(evalPointsFr)
 
+			// Naive \sum_j a_j * x^j, independent of PolyEval which PolyMultiEvaluate uses on small subtrees
 			for i := 0; i < n; i++ {
-				gmcl.FrEvaluatePolynomial(&evaluations[i], polynomialFr, &evalPointsFr[i])
+				var xPow, term ff.Fr
+				ff.CopyFr(&xPow, &ff.ONE)
+				for j := range polynomialFr {
+					ff.FrMul(&term, &polynomialFr[j], &xPow)
+					ff.FrAdd(&evaluations[i], &evaluations[i], &term)
+					ff.FrMul(&xPow, &xPow, &evalPointsFr[i])
+				}
 			}
 			ansFr := PolyMultiEvaluate(polynomialFr, M)
 
@@ -610,19 +639,19 @@ func TestPolyMultiPointEval(t *testing.T) {
 func TestPolyTreeVec(t *testing.T) {
 
 	aLen := uint64(25)
-	aFr := make([]gmcl.Fr, aLen)
+	aFr := make([]ff.Fr, aLen)
 	for i := uint64(0); i < aLen; i++ {
 		aFr[i].Random()
 	}
 	N := nextPowOf2(uint64(aLen))
-	M := make([][]gmcl.Fr, N)
+	M := make([][]ff.Fr, N)
 	for j := uint64(0); j < N; j++ {
 		if j < aLen {
-			M[j] = make([]gmcl.Fr, 2)
-			gmcl.FrNeg(&M[j][0], &aFr[j])
+			M[j] = make([]ff.Fr, 2)
+			ff.FrNeg(&M[j][0], &aFr[j])
 			M[j][1].SetInt64(1)
 		} else {
-			M[j] = make([]gmcl.Fr, 1)
+			M[j] = make([]ff.Fr, 1)
 			M[j][0].SetInt64(1)
 		}
 	}
@@ -633,4 +662,21 @@ func TestPolyTreeVec(t *testing.T) {
 	if flag == false {
 		t.Errorf("PolyTreeVec: Answer did not match with expected.")
 	}
+
+	// Padding must not leak into the spare capacity of the inputs
+	all := make([]ff.Fr, 5)
+	for i := range all {
+		all[i].Random()
+	}
+	want := make([]ff.Fr, len(all))
+	copy(want, all)
+	padding := &M[aLen][0]
+	PolyTree(all[:3])
+	PolyTreeVec(M[:aLen])
+	if CheckEqualVec(all, want) == false {
+		t.Errorf("PolyTree: Modified the input beyond its length.")
+	}
+	if &M[aLen][0] != padding {
+		t.Errorf("PolyTreeVec: Modified the input beyond its length.")
+	}
 }
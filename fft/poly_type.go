@@ -0,0 +1,146 @@
+package fft
+
+import (
+	"github.com/accumulators-agg/go-poly/ff"
+)
+
+// Polynomial in coefficient form, lowest degree first.
+// The coefficients are always normalized: no trailing zeros, so Degree is len - 1.
+// The zero polynomial has no coefficients and degree -1, and the zero value of Poly is the zero polynomial.
+// A Poly is never modified once built, every operation returns a new one.
+type Poly struct {
+	coeffs []ff.Fr
+}
+
+// Builds a polynomial from a copy of the given coefficients, lowest degree first.
+// An empty slice, like [0] or [0, 0], is the zero polynomial.
+func NewPoly(coeffs []ff.Fr) Poly {
+	c := make([]ff.Fr, len(coeffs), len(coeffs))
+	copy(c, coeffs)
+	return wrapPoly(c)
+}
+
+// Builds a polynomial without copying, the caller must not modify a afterwards.
+func wrapPoly(a []ff.Fr) Poly {
+	i := len(a)
+	for i > 0 && a[i-1].IsZero() {
+		i--
+	}
+	return Poly{coeffs: a[:i]}
+}
+
+// Returns the vector form used by the free functions of this package, [0] for the zero polynomial.
+func (p Poly) vec() []ff.Fr {
+	if len(p.coeffs) == 0 {
+		return []ff.Fr{ff.ZERO}
+	}
+	return p.coeffs
+}
+
+// Returns a copy of the coefficients, lowest degree first.
+// The zero polynomial gives [0], like PolyCondense.
+func (p Poly) Coeffs() []ff.Fr {
+	c := make([]ff.Fr, len(p.vec()))
+	copy(c, p.vec())
+	return c
+}
+
+// Returns the degree, or -1 for the zero polynomial.
+func (p Poly) Degree() int {
+	return len(p.coeffs) - 1
+}
+
+// Returns true if p is the zero polynomial.
+func (p Poly) IsZero() bool {
+	return len(p.coeffs) == 0
+}
+
+// Returns the coefficient of x^i, zero above the degree.
+func (p Poly) Coeff(i int) ff.Fr {
+	if i < 0 || i >= len(p.coeffs) {
+		return ff.ZERO
+	}
+	return p.coeffs[i]
+}
+
+// Returns the coefficient of x^Degree, zero for the zero polynomial.
+func (p Poly) LeadingCoeff() ff.Fr {
+	return p.Coeff(p.Degree())
+}
+
+// Returns true if p and q have the same coefficients.
+func (p Poly) Equal(q Poly) bool {
+	if len(p.coeffs) != len(q.coeffs) {
+		return false
+	}
+	for i := range p.coeffs {
+		if !p.coeffs[i].IsEqual(&q.coeffs[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// Computes p(x) + q(x)
+func (p Poly) Add(q Poly) Poly {
+	return wrapPoly(PolyAdd(p.vec(), q.vec()))
+}
+
+// Computes p(x) - q(x)
+func (p Poly) Sub(q Poly) Poly {
+	return wrapPoly(PolySub(p.vec(), q.vec()))
+}
+
+// Computes p(x) * q(x)
+func (p Poly) Mul(q Poly) Poly {
+	return wrapPoly(PolyMul(p.vec(), q.vec()))
+}
+
+// Computes quotient and remainder s.t. p(x) = quo(x) * q(x) + rem(x) with deg(rem) < deg(q).
+// Panics if q is the zero polynomial.
+func (p Poly) DivMod(q Poly) (quo Poly, rem Poly) {
+	if q.IsZero() {
+		panic("Poly.DivMod: Cannot divide by zero polynomial.")
+	}
+	if p.Degree() < q.Degree() {
+		return Poly{}, p
+	}
+	a, b := PolyDiv(p.vec(), q.vec())
+	return wrapPoly(a), wrapPoly(b)
+}
+
+// Computes p(x)
+func (p Poly) Eval(x *ff.Fr) ff.Fr {
+	return PolyEval(p.coeffs, x)
+}
+
+// Computes the formal derivative p'(x)
+func (p Poly) Derivative() Poly {
+	if p.Degree() < 1 {
+		return Poly{}
+	}
+	return wrapPoly(PolyDifferentiate(p.coeffs))
+}
+
+// Computes the monic greatest common divisor of p and q.
+// The GCD of two zero polynomials is the zero polynomial.
+func (p Poly) GCD(q Poly) Poly {
+	g, _, _ := p.XGCD(q)
+	return g
+}
+
+// Extended GCD: computes u(x) and v(x) s.t. u(x) * p(x) + v(x) * q(x) = g(x),
+// with g the monic greatest common divisor of p and q.
+func (p Poly) XGCD(q Poly) (g Poly, u Poly, v Poly) {
+	if p.IsZero() && q.IsZero() {
+		return Poly{}, Poly{}, Poly{}
+	}
+	gv, uv, vv := XGCD(p.vec(), q.vec())
+	g, u, v = wrapPoly(gv), wrapPoly(uv), wrapPoly(vv)
+
+	// Scale by the inverse of the leading coefficient to make g monic.
+	var lc ff.Fr
+	ff.FrInv(&lc, &g.coeffs[g.Degree()])
+	scale := Poly{coeffs: []ff.Fr{lc}}
+	return g.Mul(scale), u.Mul(scale), v.Mul(scale)
+}
@@ -0,0 +1,136 @@
+package fft
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/accumulators-agg/go-poly/ff"
+)
+
+func polyFromInt64(a []int64) Poly {
+	return NewPoly(ff.FromInt64Vec(a))
+}
+
+func TestPolyZero(t *testing.T) {
+	for counter, p := range []Poly{{}, NewPoly(nil), NewPoly([]ff.Fr{}), polyFromInt64([]int64{0}), polyFromInt64([]int64{0, 0, 0})} {
+		testname := fmt.Sprintf("%d", counter+1)
+		t.Run(testname, func(t *testing.T) {
+			if p.IsZero() == false || p.Degree() != -1 {
+				t.Errorf("Poly: Expected the zero polynomial, got degree %d", p.Degree())
+			}
+			if p.Equal(Poly{}) == false {
+				t.Errorf("Poly: Zero polynomials are not equal")
+			}
+			if CheckEqualVec(p.Coeffs(), ff.FromInt64Vec([]int64{0})) == false {
+				t.Errorf("Poly: Expected [0] as coefficients of the zero polynomial")
+			}
+			one := polyFromInt64([]int64{1})
+			if p.Add(one).Equal(one) == false || one.Sub(p).Equal(one) == false || p.Mul(one).IsZero() == false {
+				t.Errorf("Poly: Arithmetic with the zero polynomial is wrong")
+			}
+			if p.Derivative().IsZero() == false {
+				t.Errorf("Poly: Derivative of the zero polynomial must be zero")
+			}
+			x := *ff.RandomFr()
+			if y := p.Eval(&x); y.IsZero() == false {
+				t.Errorf("Poly: Zero polynomial must evaluate to zero")
+			}
+		})
+	}
+}
+
+func TestPolyArith(t *testing.T) {
+
+	var tests = []struct {
+		a, b, sum, diff, prod, quo, rem, deriv []int64
+	}{
+		{[]int64{1, 2, 3, 4}, []int64{5, 1}, []int64{6, 3, 3, 4}, []int64{-4, 1, 3, 4}, []int64{5, 11, 17, 23, 4}, []int64{87, -17, 4}, []int64{-434}, []int64{2, 6, 12}},
+		{[]int64{8, 10, -5, 3, 0}, []int64{-3, 2, 1}, []int64{5, 12, -4, 3}, []int64{11, 8, -6, 3}, []int64{-24, -14, 43, -9, 1, 3}, []int64{-11, 3}, []int64{-25, 41}, []int64{10, -10, 9}},
+		{[]int64{1, 2}, []int64{-3, 2, 1}, []int64{-2, 4, 1}, []int64{4, 0, -1}, []int64{-3, -4, 5, 2}, []int64{}, []int64{1, 2}, []int64{2}},
+		{[]int64{7}, []int64{7}, []int64{14}, []int64{}, []int64{49}, []int64{1}, []int64{}, []int64{}},
+	}
+
+	for counter, tt := range tests {
+		testname := fmt.Sprintf("%d", counter+1)
+		t.Run(testname, func(t *testing.T) {
+			a := polyFromInt64(tt.a)
+			b := polyFromInt64(tt.b)
+
+			if a.Add(b).Equal(polyFromInt64(tt.sum)) == false {
+				t.Errorf("Poly.Add: Answer did not match with expected.")
+			}
+			if a.Sub(b).Equal(polyFromInt64(tt.diff)) == false {
+				t.Errorf("Poly.Sub: Answer did not match with expected.")
+			}
+			if a.Mul(b).Equal(polyFromInt64(tt.prod)) == false {
+				t.Errorf("Poly.Mul: Answer did not match with expected.")
+			}
+			quo, rem := a.DivMod(b)
+			if quo.Equal(polyFromInt64(tt.quo)) == false {
+				t.Errorf("Poly.DivMod: Quotient did not match with expected.")
+			}
+			if rem.Equal(polyFromInt64(tt.rem)) == false {
+				t.Errorf("Poly.DivMod: Remainder did not match with expected.")
+			}
+			if a.Derivative().Equal(polyFromInt64(tt.deriv)) == false {
+				t.Errorf("Poly.Derivative: Answer did not match with expected.")
+			}
+
+			var x, y ff.Fr
+			x.SetInt64(3)
+			y.SetInt64(0)
+			for i := len(tt.a) - 1; i >= 0; i-- {
+				var c ff.Fr
+				c.SetInt64(tt.a[i])
+				ff.FrMul(&y, &y, &x)
+				ff.FrAdd(&y, &y, &c)
+			}
+			if got := a.Eval(&x); got.IsEqual(&y) == false {
+				t.Errorf("Poly.Eval: Answer did not match with expected.")
+			}
+		})
+	}
+}
+
+func TestPolyGCD(t *testing.T) {
+	var tests = []struct {
+		aLen, bLen, commonLen int
+	}{
+		{4, 4, 1},
+		{20, 3, 2},
+		{1, 5, 1},
+		{140, 130, 7},
+	}
+
+	for counter, tt := range tests {
+		testname := fmt.Sprintf("%d", counter+1)
+		t.Run(testname, func(t *testing.T) {
+			common := NewPoly(randomPoly(tt.commonLen))
+			a := NewPoly(randomPoly(tt.aLen)).Mul(common)
+			b := NewPoly(randomPoly(tt.bLen)).Mul(common)
+
+			g, u, v := a.XGCD(b)
+			if g.Equal(a.GCD(b)) == false {
+				t.Fatalf("Poly.GCD: Answer did not match with Poly.XGCD.")
+			}
+			if lc := g.LeadingCoeff(); lc.IsOne() == false {
+				t.Errorf("Poly.GCD: Expected a monic polynomial.")
+			}
+			if u.Mul(a).Add(v.Mul(b)).Equal(g) == false {
+				t.Errorf("Poly.XGCD: u * a + v * b did not match with g.")
+			}
+			for _, p := range []Poly{a, b} {
+				if _, rem := p.DivMod(g); rem.IsZero() == false {
+					t.Errorf("Poly.GCD: g does not divide the inputs.")
+				}
+			}
+			if _, rem := g.DivMod(common); rem.IsZero() == false {
+				t.Errorf("Poly.GCD: The common factor does not divide g.")
+			}
+		})
+	}
+
+	if g := (Poly{}).GCD(Poly{}); g.IsZero() == false {
+		t.Errorf("Poly.GCD: The GCD of two zero polynomials must be zero.")
+	}
+}
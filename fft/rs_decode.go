@@ -0,0 +1,120 @@
+package fft
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/accumulators-agg/go-poly/ff"
+)
+
+// Reed-Solomon decoding with errors, following Gao's algorithm
+// (S. Gao, "A New Algorithm for Decoding Reed-Solomon Codes", 2003):
+// with g0 = \prod_i (x - xs_i) and g1 the interpolant of the received values, the extended Euclid
+// on (g0, g1) stopped at the first remainder g of degree < (n + k) / 2 gives g = u * g0 + v * g1,
+// and f = g / v is the sent polynomial if at most (n - k) / 2 values are wrong.
+// The roots of v are then the error locations.
+
+// Finds f of degree < k from g0 and g1 as above, or returns ErrTooManyErrors.
+func (fs *FFTSettings) gaoDecode(g0 []ff.Fr, g1 []ff.Fr, n int, k int) ([]ff.Fr, error) {
+	g, _, v := fs.XGCDUntil(g0, g1, (n+k+1)/2)
+	if IsPolyZero(g) {
+		// v * g1 = 0 mod g0: the non-zero values are at roots of v, at most deg(v) <= (n - k) / 2 of them,
+		// so the zero polynomial is within the errors the code corrects
+		return []ff.Fr{ff.ZERO}, nil
+	}
+	f, r, err := fs.TryPolyDiv(g, v)
+	if err != nil && errors.Is(err, ErrDegreeMismatch) == false {
+		return nil, err
+	}
+	if err != nil || IsPolyZero(r) == false || polyDegree(f) >= k {
+		return nil, fmt.Errorf("%w: no polynomial of degree < %d is within %d errors", ErrTooManyErrors, k, (n-k)/2)
+	}
+	return f, nil
+}
+
+// The products and divisions in the decoding have up to n + 1 coefficients.
+func (fs *FFTSettings) checkDecodeSizes(n int, k int) error {
+	if n == 0 {
+		return ErrEmptyInput
+	}
+	if k < 1 || k > n {
+		return fmt.Errorf("%w: cannot decode a polynomial of degree < %d from %d values", ErrDegreeMismatch, k, n)
+	}
+	return fs.checkMulWidth(n + 1)
+}
+
+// Returns the indices where the values differ from want
+func errorLocations(values []ff.Fr, want []ff.Fr) []int {
+	var locs []int
+	for i := range values {
+		if values[i].IsEqual(&want[i]) == false {
+			locs = append(locs, i)
+		}
+	}
+	return locs
+}
+
+// Decodes the polynomial f of degree < k from its values ys_i = f(xs_i) at n distinct points,
+// of which up to (n - k) / 2 may be wrong. Returns f and the indices of the wrong values.
+// Returns ErrTooManyErrors if no such f exists. With more errors than that, another polynomial
+// may be closer to the values, and is returned instead.
+func DecodeRS(xs []ff.Fr, ys []ff.Fr, k int) ([]ff.Fr, []int, error) {
+	return cachedSettings.DecodeRS(xs, ys, k)
+}
+
+// Same as DecodeRS, using the roots of unity of fs.
+// Returns ErrDomainTooSmall if fs is not wide enough for the products of n + 1 coefficients.
+func (fs *FFTSettings) DecodeRS(xs []ff.Fr, ys []ff.Fr, k int) ([]ff.Fr, []int, error) {
+	n := len(xs)
+	if n != len(ys) {
+		return nil, nil, fmt.Errorf("DecodeRS: %w: got %d points but %d values", ErrDegreeMismatch, n, len(ys))
+	}
+	if err := fs.checkDecodeSizes(n, k); err != nil {
+		return nil, nil, fmt.Errorf("DecodeRS: %w", err)
+	}
+
+	M := fs.subProductTree(xs)
+	g0 := M[len(M)-1][0]
+	g1 := fs.PolyInterpolate(xs, ys)
+	f, err := fs.gaoDecode(g0, g1, n, k)
+	if err != nil {
+		return nil, nil, fmt.Errorf("DecodeRS: %w", err)
+	}
+
+	evals := fs.PolyMultiEvaluate(f, M)
+	return f, errorLocations(ys, evals[:n]), nil
+}
+
+// Same as DecodeRS for the values at the n-th roots of unity, n a power of two,
+// such as the output of ExtendData with k = n / 2.
+// Interpolation and evaluation are FFTs and g0 = x^n - 1.
+// fs must have at least 4n roots of unity for the products of the decoding.
+func (fs *FFTSettings) DecodeData(values []ff.Fr, k int) ([]ff.Fr, []int, error) {
+	n := len(values)
+	if err := fs.checkDecodeSizes(n, k); err != nil {
+		return nil, nil, fmt.Errorf("DecodeData: %w", err)
+	}
+	if !ff.IsPowerOfTwo(uint64(n)) {
+		return nil, nil, fmt.Errorf("DecodeData: %w: got %d values", ErrNotPowerOfTwo, n)
+	}
+
+	g0 := make([]ff.Fr, n+1, n+1)
+	ff.FrNeg(&g0[0], &ff.ONE)
+	g0[n] = ff.ONE
+	g1, err := fs.FFT(values, true)
+	if err != nil {
+		return nil, nil, fmt.Errorf("DecodeData: %w", err)
+	}
+	f, err := fs.gaoDecode(g0, g1, n, k)
+	if err != nil {
+		return nil, nil, fmt.Errorf("DecodeData: %w", err)
+	}
+
+	padded := make([]ff.Fr, n, n)
+	copy(padded, f)
+	evals, err := fs.FFT(padded, false)
+	if err != nil {
+		return nil, nil, fmt.Errorf("DecodeData: %w", err)
+	}
+	return f, errorLocations(values, evals), nil
+}
@@ -0,0 +1,27 @@
+package fft
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+func BenchmarkFFTSettings_DecodeData(b *testing.B) {
+	for scale := uint8(4); scale < 11; scale += 2 {
+		b.Run(fmt.Sprintf("scale_%d", scale), func(b *testing.B) {
+			// The decoding multiplies polynomials of up to 2^scale + 1 coefficients
+			fs := NewFFTSettings(scale + 2)
+			values, err := fs.ExtendData(randomPoly(1 << (scale - 1)))
+			if err != nil {
+				b.Fatal(err)
+			}
+			corruptValues(values, len(values)/4, rand.New(rand.NewSource(1)))
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if _, _, err := fs.DecodeData(values, len(values)/2); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
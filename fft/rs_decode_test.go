@@ -0,0 +1,175 @@
+package fft
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/accumulators-agg/go-poly/ff"
+)
+
+// Adds a random non-zero value to the given number of random entries, returns the sorted indices
+func corruptValues(values []ff.Fr, count int, rng *rand.Rand) []int {
+	locs := rng.Perm(len(values))[:count]
+	sort.Ints(locs)
+	for _, i := range locs {
+		var e ff.Fr
+		ff.IntAsFr(&e, int64(rng.Intn(1000)+1))
+		ff.FrAdd(&values[i], &values[i], &e)
+	}
+	return locs
+}
+
+func checkEqualInts(a []int, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestDecodeRS(t *testing.T) {
+	rng := rand.New(rand.NewSource(2))
+	var tests = []struct {
+		n, k int
+	}{
+		{1, 1},
+		{5, 1},
+		{7, 3},
+		{10, 4},
+		{33, 10},
+		{100, 50},
+	}
+
+	for _, tt := range tests {
+		for _, errs := range []int{0, 1, (tt.n - tt.k) / 2} {
+			if errs > (tt.n-tt.k)/2 {
+				continue
+			}
+			t.Run(fmt.Sprintf("%d-%d-%d", tt.n, tt.k, errs), func(t *testing.T) {
+				f := PolyCondense(randomPoly(tt.k))
+				xs := randomPoly(tt.n)
+				ys := PolyEvalMany(f, xs)
+				want := corruptValues(ys, errs, rng)
+
+				got, locs, err := DecodeRS(xs, ys, tt.k)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if IsPolyEqual(got, f) == false {
+					t.Errorf("DecodeRS: Answer did not match with the sent polynomial.")
+				}
+				if checkEqualInts(locs, want) == false {
+					t.Errorf("DecodeRS: expected error locations %v, got %v", want, locs)
+				}
+			})
+		}
+	}
+}
+
+func TestDecodeData(t *testing.T) {
+	fs := NewFFTSettings(9)
+	rng := rand.New(rand.NewSource(3))
+	for _, n := range []int{2, 16, 128} {
+		for _, errs := range []int{0, 1, n / 4} {
+			if errs > n/4 {
+				continue
+			}
+			t.Run(fmt.Sprintf("%d-%d", n, errs), func(t *testing.T) {
+				data := randomPoly(n / 2)
+				values, err := fs.ExtendData(data)
+				if err != nil {
+					t.Fatal(err)
+				}
+				want := corruptValues(values, errs, rng)
+
+				f, locs, err := fs.DecodeData(values, n/2)
+				if err != nil {
+					t.Fatal(err)
+				}
+				coeffs, _ := fs.FFT(data, true)
+				if IsPolyEqual(f, PolyCondense(coeffs)) == false {
+					t.Errorf("DecodeData: Answer did not match with the extended data.")
+				}
+				if checkEqualInts(locs, want) == false {
+					t.Errorf("DecodeData: expected error locations %v, got %v", want, locs)
+				}
+			})
+		}
+	}
+}
+
+func TestDecodeZeroCodeword(t *testing.T) {
+	fs := NewFFTSettings(6)
+	rng := rand.New(rand.NewSource(5))
+	for _, errs := range []int{0, 1, 4} {
+		t.Run(fmt.Sprintf("%d", errs), func(t *testing.T) {
+			values := make([]ff.Fr, 16)
+			want := corruptValues(values, errs, rng)
+			f, locs, err := fs.DecodeData(values, 8)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if IsPolyZero(f) == false {
+				t.Errorf("DecodeData: expected the zero polynomial")
+			}
+			if checkEqualInts(locs, want) == false {
+				t.Errorf("DecodeData: expected error locations %v, got %v", want, locs)
+			}
+
+			ys := make([]ff.Fr, 13)
+			want = corruptValues(ys, errs, rng)
+			f, locs, err = DecodeRS(randomPoly(len(ys)), ys, 5)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if IsPolyZero(f) == false {
+				t.Errorf("DecodeRS: expected the zero polynomial")
+			}
+			if checkEqualInts(locs, want) == false {
+				t.Errorf("DecodeRS: expected error locations %v, got %v", want, locs)
+			}
+		})
+	}
+}
+
+func TestDecodeRSErrors(t *testing.T) {
+	fs := NewFFTSettings(6)
+	rng := rand.New(rand.NewSource(4))
+
+	// Random values are far from any polynomial of degree < 2
+	tooMany := randomPoly(16)
+	// One error more than the code corrects
+	values, _ := fs.ExtendData(randomPoly(8))
+	corruptValues(values, 5, rng)
+
+	var tests = []struct {
+		name string
+		run  func() error
+		want error
+	}{
+		{"empty", func() error { _, _, err := DecodeRS(nil, nil, 1); return err }, ErrEmptyInput},
+		{"lengths", func() error { _, _, err := DecodeRS(randomPoly(3), randomPoly(2), 1); return err }, ErrDegreeMismatch},
+		{"k-zero", func() error { _, _, err := DecodeRS(randomPoly(3), randomPoly(3), 0); return err }, ErrDegreeMismatch},
+		{"k-large", func() error { _, _, err := DecodeRS(randomPoly(3), randomPoly(3), 4); return err }, ErrDegreeMismatch},
+		{"random", func() error { _, _, err := fs.DecodeData(tooMany, 2); return err }, ErrTooManyErrors},
+		{"random-points", func() error { _, _, err := DecodeRS(randomPoly(16), tooMany, 2); return err }, ErrTooManyErrors},
+		{"one-more", func() error { _, _, err := fs.DecodeData(values, 8); return err }, ErrTooManyErrors},
+		{"pow2", func() error { _, _, err := fs.DecodeData(randomPoly(12), 4); return err }, ErrNotPowerOfTwo},
+		{"domain", func() error { _, _, err := fs.DecodeData(randomPoly(32), 4); return err }, ErrDomainTooSmall},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.run(); errors.Is(err, tt.want) == false {
+				t.Errorf("expected %v, got %v", tt.want, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,130 @@
+package fft
+
+import (
+	"fmt"
+
+	"github.com/accumulators-agg/go-poly/ff"
+)
+
+// Roots multiplied directly into one leaf polynomial, whose degree stays small enough
+// for the quadratic product to beat the FFTs.
+const zeroPolyLeafSize = 64
+
+// Number of polynomials multiplied together with one set of FFTs when reducing the leaves.
+const zeroPolyReduction = 4
+
+// Returns p * (x - r), reusing the storage of p
+func mulByLinear(p []ff.Fr, r *ff.Fr) []ff.Fr {
+	p = append(p, ff.ZERO)
+	var tmp ff.Fr
+	for i := len(p) - 1; i > 0; i-- {
+		ff.FrMul(&tmp, r, &p[i])
+		ff.FrSub(&p[i], &p[i-1], &tmp)
+	}
+	ff.FrMul(&tmp, r, &p[0])
+	ff.FrNeg(&p[0], &tmp)
+	return p
+}
+
+// Multiplies the polynomials in the evaluation domain of the smallest power of two fitting the product:
+// one FFT per polynomial, pointwise products, one inverse FFT.
+func (fs *FFTSettings) mulPolysViaFFT(ps [][]ff.Fr) ([]ff.Fr, error) {
+	if len(ps) == 1 {
+		return ps[0], nil
+	}
+	prodLen := 1
+	for _, p := range ps {
+		prodLen += len(p) - 1
+	}
+	m := nextPowOf2(uint64(prodLen))
+
+	padded := make([]ff.Fr, m, m)
+	var acc []ff.Fr
+	for k, p := range ps {
+		copy(padded, p)
+		for i := len(p); i < len(padded); i++ {
+			padded[i] = ff.ZERO
+		}
+		evals, err := fs.FFT(padded, false)
+		if err != nil {
+			return nil, err
+		}
+		if k == 0 {
+			acc = evals
+			continue
+		}
+		for i := range acc {
+			ff.FrMul(&acc[i], &acc[i], &evals[i])
+		}
+	}
+	out, err := fs.FFT(acc, true)
+	if err != nil {
+		return nil, err
+	}
+	return out[:prodLen], nil
+}
+
+// Computes the zero polynomial Z(x) = \prod_{i in indices} (x - w^i), where w is the primitive
+// domainSize-th root of unity, both in coefficient form and evaluated over the domain, each padded
+// to domainSize entries. Unlike PolyTree, it builds leaves of zeroPolyLeafSize roots directly and
+// multiplies them zeroPolyReduction at a time in the evaluation domain.
+// There must be fewer indices than domainSize, so that Z fits, and every index must be in the domain.
+func (fs *FFTSettings) ZeroPolyViaMultiplication(indices []uint64, domainSize uint64) ([]ff.Fr, []ff.Fr, error) {
+	if !ff.IsPowerOfTwo(domainSize) {
+		return nil, nil, fmt.Errorf("ZeroPolyViaMultiplication: %w: domain size %d", ErrNotPowerOfTwo, domainSize)
+	}
+	fs, err := fs.forWidth(domainSize)
+	if err != nil {
+		return nil, nil, fmt.Errorf("ZeroPolyViaMultiplication: %w", err)
+	}
+	if uint64(len(indices)) >= domainSize {
+		return nil, nil, fmt.Errorf("ZeroPolyViaMultiplication: %w: %d indices do not fit a domain of size %d",
+			ErrDegreeMismatch, len(indices), domainSize)
+	}
+	stride := fs.MaxWidth / domainSize
+
+	polys := make([][]ff.Fr, 0, (len(indices)+zeroPolyLeafSize-1)/zeroPolyLeafSize)
+	for start := 0; start < len(indices); start += zeroPolyLeafSize {
+		end := start + zeroPolyLeafSize
+		if end > len(indices) {
+			end = len(indices)
+		}
+		leaf := make([]ff.Fr, 1, end-start+1)
+		leaf[0] = ff.ONE
+		for _, idx := range indices[start:end] {
+			if idx >= domainSize {
+				return nil, nil, fmt.Errorf("ZeroPolyViaMultiplication: %w: index %d, domain size %d", ErrIndexOutOfDomain, idx, domainSize)
+			}
+			leaf = mulByLinear(leaf, &fs.ExpandedRootsOfUnity[idx*stride])
+		}
+		polys = append(polys, leaf)
+	}
+
+	for len(polys) > 1 {
+		next := make([][]ff.Fr, 0, (len(polys)+zeroPolyReduction-1)/zeroPolyReduction)
+		for start := 0; start < len(polys); start += zeroPolyReduction {
+			end := start + zeroPolyReduction
+			if end > len(polys) {
+				end = len(polys)
+			}
+			p, err := fs.mulPolysViaFFT(polys[start:end])
+			if err != nil {
+				return nil, nil, fmt.Errorf("ZeroPolyViaMultiplication: %w", err)
+			}
+			next = append(next, p)
+		}
+		polys = next
+	}
+
+	coeffs := make([]ff.Fr, domainSize, domainSize)
+	if len(polys) == 0 {
+		coeffs[0] = ff.ONE
+	} else {
+		copy(coeffs, polys[0])
+	}
+	evals, err := fs.FFT(coeffs, false)
+	if err != nil {
+		return nil, nil, fmt.Errorf("ZeroPolyViaMultiplication: %w", err)
+	}
+	return coeffs, evals, nil
+}
@@ -0,0 +1,36 @@
+package fft
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/accumulators-agg/go-poly/ff"
+)
+
+func BenchmarkFFTSettings_ZeroPolyViaMultiplication(b *testing.B) {
+	for scale := uint8(6); scale < 15; scale += 2 {
+		fs := NewFFTSettings(scale)
+		indices := make([]uint64, fs.MaxWidth/2)
+		for i, j := range rand.New(rand.NewSource(1)).Perm(int(fs.MaxWidth))[:len(indices)] {
+			indices[i] = uint64(j)
+		}
+		b.Run(fmt.Sprintf("scale_%d", scale), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, _, err := fs.ZeroPolyViaMultiplication(indices, fs.MaxWidth); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("scale_%d_tree", scale), func(b *testing.B) {
+			roots := make([]ff.Fr, len(indices))
+			for i, j := range indices {
+				roots[i] = fs.ExpandedRootsOfUnity[j]
+			}
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				fs.PolyTree(roots)
+			}
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package fft
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/accumulators-agg/go-poly/ff"
+)
+
+func TestZeroPolyViaMultiplication(t *testing.T) {
+	fs := NewFFTSettings(10)
+	rng := rand.New(rand.NewSource(3))
+
+	var tests = []struct {
+		domainSize uint64
+		count      int
+	}{
+		{2, 0},
+		{2, 1},
+		{16, 5},
+		{64, 63},
+		{256, 64},
+		{256, 65},
+		{1024, 300},
+		{1024, 1023},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%d-%d", tt.domainSize, tt.count), func(t *testing.T) {
+			indices := make([]uint64, tt.count)
+			for i, j := range rng.Perm(int(tt.domainSize))[:tt.count] {
+				indices[i] = uint64(j)
+			}
+			coeffs, evals, err := fs.ZeroPolyViaMultiplication(indices, tt.domainSize)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if uint64(len(coeffs)) != tt.domainSize || uint64(len(evals)) != tt.domainSize {
+				t.Fatalf("ZeroPolyViaMultiplication: expected %d entries, got %d and %d", tt.domainSize, len(coeffs), len(evals))
+			}
+
+			stride := fs.MaxWidth / tt.domainSize
+			roots := make([]ff.Fr, tt.count)
+			for i, j := range indices {
+				roots[i] = fs.ExpandedRootsOfUnity[j*stride]
+			}
+			want := make([]ff.Fr, tt.domainSize)
+			want[0] = ff.ONE
+			if tt.count > 0 {
+				copy(want, PolyTree(roots))
+			}
+			if CheckEqualVec(coeffs, want) == false {
+				t.Errorf("ZeroPolyViaMultiplication: coefficients did not match with PolyTree.")
+			}
+
+			// Zero exactly at the given indices
+			missing := make(map[uint64]bool)
+			for _, j := range indices {
+				missing[j] = true
+			}
+			for j := uint64(0); j < tt.domainSize; j++ {
+				if evals[j].IsZero() != missing[j] {
+					t.Errorf("ZeroPolyViaMultiplication: evaluation at index %d is wrong", j)
+				}
+			}
+		})
+	}
+}
+
+func TestZeroPolyViaMultiplicationErrors(t *testing.T) {
+	fs := NewFFTSettings(4)
+
+	var tests = []struct {
+		name       string
+		indices    []uint64
+		domainSize uint64
+		want       error
+	}{
+		{"pow2", []uint64{1}, 12, ErrNotPowerOfTwo},
+		{"domain", []uint64{1}, 32, ErrDomainTooSmall},
+		{"full", []uint64{0, 1, 2, 3}, 4, ErrDegreeMismatch},
+		{"index", []uint64{1, 8}, 8, ErrIndexOutOfDomain},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, err := fs.ZeroPolyViaMultiplication(tt.indices, tt.domainSize); errors.Is(err, tt.want) == false {
+				t.Errorf("expected %v, got %v", tt.want, err)
+			}
+		})
+	}
+}
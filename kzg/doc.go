@@ -0,0 +1,4 @@
+// Package kzg implements KZG polynomial commitments over the G1 and G2 groups of the go-mcl backend:
+// commitments to polynomials in coefficient form, and openings at one or many points
+// checked with a pairing. The package is empty with the pure Go field backend, which has no groups.
+package kzg
@@ -0,0 +1,13 @@
+package kzg
+
+import "errors"
+
+// Sentinel errors, returned errors wrap them with more context, match them with errors.Is.
+var (
+	// The SRS does not have enough powers of tau for the polynomial or the number of points.
+	ErrSRSTooSmall = errors.New("SRS is too small")
+	// An input polynomial or point set has no entries.
+	ErrEmptyInput = errors.New("empty input")
+	// The opening points are not distinct.
+	ErrDuplicatePoints = errors.New("points are not distinct")
+)
@@ -0,0 +1,161 @@
+//go:build !bignum_pure && !bignum_hol256 && !bignum_kilic && !bignum_hbls
+// +build !bignum_pure,!bignum_hol256,!bignum_kilic,!bignum_hbls
+
+package kzg
+
+import (
+	"fmt"
+	"runtime"
+
+	"github.com/accumulators-agg/go-poly/ff"
+	"github.com/accumulators-agg/go-poly/fft"
+	gmcl "github.com/alinush/go-mcl"
+)
+
+// Computes the commitment [p(tau)]G1 to the polynomial p in coefficient form, lowest degree first.
+// Returns ErrSRSTooSmall if p has more coefficients, trailing zeros aside, than srs.G1.
+func (srs *SRS) Commit(poly []ff.Fr) (gmcl.G1, error) {
+	var c gmcl.G1
+	if len(poly) == 0 {
+		return c, fmt.Errorf("Commit: %w", ErrEmptyInput)
+	}
+	poly = fft.PolyCondense(poly)
+	if len(poly) > len(srs.G1) {
+		return c, fmt.Errorf("Commit: %w: %d coefficients but %d powers in G1", ErrSRSTooSmall, len(poly), len(srs.G1))
+	}
+	ff.MSMG1Parallel(&c, srs.G1[:len(poly)], poly, runtime.GOMAXPROCS(0))
+	return c, nil
+}
+
+// Same as Commit, in G2: computes [p(tau)]G2, limited by the size of srs.G2.
+func (srs *SRS) CommitG2(poly []ff.Fr) (gmcl.G2, error) {
+	var c gmcl.G2
+	if len(poly) == 0 {
+		return c, fmt.Errorf("CommitG2: %w", ErrEmptyInput)
+	}
+	poly = fft.PolyCondense(poly)
+	if len(poly) > len(srs.G2) {
+		return c, fmt.Errorf("CommitG2: %w: %d coefficients but %d powers in G2", ErrSRSTooSmall, len(poly), len(srs.G2))
+	}
+	ff.MSMG2Parallel(&c, srs.G2[:len(poly)], poly, runtime.GOMAXPROCS(0))
+	return c, nil
+}
+
+// Opens p at z: returns y = p(z) and the proof [q(tau)]G1, where q(x) = (p(x) - y) / (x - z).
+func (srs *SRS) Open(poly []ff.Fr, z *ff.Fr) (proof gmcl.G1, y ff.Fr, err error) {
+	if len(poly) == 0 {
+		return proof, y, fmt.Errorf("Open: %w", ErrEmptyInput)
+	}
+	poly = fft.PolyCondense(poly)
+	y = fft.PolyEval(poly, z)
+	if len(poly) == 1 {
+		// Constant, the quotient is zero
+		proof.Clear()
+		return proof, y, nil
+	}
+
+	// x - z divides p(x) - y exactly
+	shifted := make([]ff.Fr, len(poly))
+	copy(shifted, poly)
+	ff.FrSub(&shifted[0], &shifted[0], &y)
+	divisor := make([]ff.Fr, 2)
+	ff.FrNeg(&divisor[0], z)
+	divisor[1].SetInt64(1)
+	q := fft.PolyLongDiv(shifted, divisor)
+
+	proof, err = srs.Commit(q)
+	if err != nil {
+		return proof, y, fmt.Errorf("Open: %w", err)
+	}
+	return proof, y, nil
+}
+
+// Checks that proof opens commitment to y at z: e(C - [y]G1, [1]G2) = e(proof, [tau - z]G2).
+// Returns false if srs lacks [1]G1 or [tau]G2.
+func (srs *SRS) Verify(commitment *gmcl.G1, proof *gmcl.G1, z *ff.Fr, y *ff.Fr) bool {
+	if len(srs.G1) < 1 || len(srs.G2) < 2 {
+		return false
+	}
+	var lhs, yG1 gmcl.G1
+	gmcl.G1Mul(&yG1, &srs.G1[0], y)
+	gmcl.G1Sub(&lhs, commitment, &yG1)
+
+	var rhs, zG2 gmcl.G2
+	gmcl.G2Mul(&zG2, &srs.G2[0], z)
+	gmcl.G2Sub(&rhs, &srs.G2[1], &zG2)
+
+	return ff.PairingsVerify(&lhs, &srs.G2[0], proof, &rhs)
+}
+
+// Checks that zs are distinct and that srs can open that many points
+func (srs *SRS) checkPoints(zs []ff.Fr) error {
+	if len(zs) == 0 {
+		return ErrEmptyInput
+	}
+	if len(zs)+1 > len(srs.G2) {
+		return fmt.Errorf("%w: %d points need %d powers in G2, got %d", ErrSRSTooSmall, len(zs), len(zs)+1, len(srs.G2))
+	}
+	seen := make(map[[ff.FrBytes]byte]struct{}, len(zs))
+	for i := range zs {
+		k := ff.FrToBytes(&zs[i], ff.LittleEndian)
+		if _, ok := seen[k]; ok {
+			return fmt.Errorf("%w: %s appears twice", ErrDuplicatePoints, ff.FrStr(&zs[i]))
+		}
+		seen[k] = struct{}{}
+	}
+	return nil
+}
+
+// Opens p at all of zs with a single proof: returns ys_i = p(zs_i) and [q(tau)]G1, where
+// q(x) is the quotient of p(x) by the vanishing polynomial Z(x) = \prod_i (x - zs_i).
+// The remainder of the division is the polynomial interpolating the ys.
+// Returns ErrDuplicatePoints if zs are not distinct and ErrSRSTooSmall if srs.G2 cannot commit to Z.
+func (srs *SRS) OpenMulti(poly []ff.Fr, zs []ff.Fr) (proof gmcl.G1, ys []ff.Fr, err error) {
+	if len(poly) == 0 {
+		return proof, nil, fmt.Errorf("OpenMulti: %w", ErrEmptyInput)
+	}
+	if err := srs.checkPoints(zs); err != nil {
+		return proof, nil, fmt.Errorf("OpenMulti: %w", err)
+	}
+	poly = fft.PolyCondense(poly)
+	ys = fft.PolyEvalMany(poly, zs)
+
+	Z := fft.PolyTree(zs)
+	if len(poly) < len(Z) {
+		// deg p < deg Z, p is its own remainder
+		proof.Clear()
+		return proof, ys, nil
+	}
+	q, _ := fft.PolyDiv(poly, Z)
+	proof, err = srs.Commit(q)
+	if err != nil {
+		return proof, nil, fmt.Errorf("OpenMulti: %w", err)
+	}
+	return proof, ys, nil
+}
+
+// Checks that proof opens commitment to ys at zs: e(C - [I(tau)]G1, [1]G2) = e(proof, [Z(tau)]G2),
+// where I interpolates the ys at the zs and Z vanishes on them.
+// Returns an error, rather than false, if the inputs do not fit together or the SRS is too small.
+func (srs *SRS) VerifyMulti(commitment *gmcl.G1, proof *gmcl.G1, zs []ff.Fr, ys []ff.Fr) (bool, error) {
+	if err := srs.checkPoints(zs); err != nil {
+		return false, fmt.Errorf("VerifyMulti: %w", err)
+	}
+	if len(zs) != len(ys) {
+		return false, fmt.Errorf("VerifyMulti: got %d points but %d values", len(zs), len(ys))
+	}
+
+	I := fft.PolyInterpolate(zs, ys)
+	iG1, err := srs.Commit(I)
+	if err != nil {
+		return false, fmt.Errorf("VerifyMulti: %w", err)
+	}
+	var lhs gmcl.G1
+	gmcl.G1Sub(&lhs, commitment, &iG1)
+
+	zG2, err := srs.CommitG2(fft.PolyTree(zs))
+	if err != nil {
+		return false, fmt.Errorf("VerifyMulti: %w", err)
+	}
+	return ff.PairingsVerify(&lhs, &srs.G2[0], proof, &zG2), nil
+}
@@ -0,0 +1,176 @@
+//go:build !bignum_pure && !bignum_hol256 && !bignum_kilic && !bignum_hbls
+// +build !bignum_pure,!bignum_hol256,!bignum_kilic,!bignum_hbls
+
+package kzg
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/accumulators-agg/go-poly/ff"
+	"github.com/accumulators-agg/go-poly/fft"
+	gmcl "github.com/alinush/go-mcl"
+)
+
+func randomPoly(n int) []ff.Fr {
+	a := make([]ff.Fr, n)
+	for i := range a {
+		a[i] = *ff.RandomFr()
+	}
+	return a
+}
+
+func TestCommit(t *testing.T) {
+	tau := ff.RandomFr()
+	srs := NewSRSInsecure(tau, 16, 2)
+
+	for _, n := range []int{1, 2, 9, 16} {
+		t.Run(fmt.Sprintf("%d", n), func(t *testing.T) {
+			p := randomPoly(n)
+			c, err := srs.Commit(p)
+			if err != nil {
+				t.Fatal(err)
+			}
+			// [p(tau)]G1 computed directly
+			var want gmcl.G1
+			y := fft.PolyEval(p, tau)
+			gmcl.G1Mul(&want, &ff.GenG1, &y)
+			if c.IsEqual(&want) == false {
+				t.Errorf("Commit: did not match [p(tau)]G1")
+			}
+		})
+	}
+
+	// Trailing zeros do not count against the SRS
+	p := append(randomPoly(16), ff.ZERO, ff.ZERO)
+	if _, err := srs.Commit(p); err != nil {
+		t.Errorf("Commit: unexpected error for trailing zeros, %v", err)
+	}
+	if _, err := srs.Commit(randomPoly(17)); errors.Is(err, ErrSRSTooSmall) == false {
+		t.Errorf("Commit: expected %v, got %v", ErrSRSTooSmall, err)
+	}
+	if _, err := srs.Commit(nil); errors.Is(err, ErrEmptyInput) == false {
+		t.Errorf("Commit: expected %v, got %v", ErrEmptyInput, err)
+	}
+}
+
+func TestOpenVerify(t *testing.T) {
+	srs := NewSRSInsecure(ff.RandomFr(), 32, 2)
+
+	for _, n := range []int{1, 2, 5, 32} {
+		t.Run(fmt.Sprintf("%d", n), func(t *testing.T) {
+			p := randomPoly(n)
+			c, err := srs.Commit(p)
+			if err != nil {
+				t.Fatal(err)
+			}
+			z := ff.RandomFr()
+			proof, y, err := srs.Open(p, z)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want := fft.PolyEval(p, z); y.IsEqual(&want) == false {
+				t.Errorf("Open: y did not match p(z)")
+			}
+			if srs.Verify(&c, &proof, z, &y) == false {
+				t.Errorf("Verify: rejected a valid opening")
+			}
+
+			var wrong ff.Fr
+			ff.FrAdd(&wrong, &y, &ff.ONE)
+			if srs.Verify(&c, &proof, z, &wrong) {
+				t.Errorf("Verify: accepted a wrong value")
+			}
+			if n > 1 && srs.Verify(&c, &proof, ff.RandomFr(), &y) {
+				t.Errorf("Verify: accepted a wrong point")
+			}
+		})
+	}
+}
+
+func TestVerifyShortSRS(t *testing.T) {
+	srs := NewSRSInsecure(ff.RandomFr(), 4, 2)
+	p := randomPoly(4)
+	c, _ := srs.Commit(p)
+	z := ff.RandomFr()
+	proof, y, _ := srs.Open(p, z)
+
+	for _, short := range []*SRS{{G1: srs.G1, G2: srs.G2[:1]}, {G1: nil, G2: srs.G2}, {}} {
+		if short.Verify(&c, &proof, z, &y) {
+			t.Errorf("Verify: accepted an opening with %d powers in G1 and %d in G2", len(short.G1), len(short.G2))
+		}
+	}
+}
+
+func TestOpenVerifyMulti(t *testing.T) {
+	srs := NewSRSInsecure(ff.RandomFr(), 64, 17)
+
+	var tests = []struct {
+		n, k int
+	}{
+		{1, 1},
+		{5, 1},
+		{5, 3},
+		{5, 8},
+		{64, 16},
+		{64, 13},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%d-%d", tt.n, tt.k), func(t *testing.T) {
+			p := randomPoly(tt.n)
+			zs := randomPoly(tt.k)
+			c, err := srs.Commit(p)
+			if err != nil {
+				t.Fatal(err)
+			}
+			proof, ys, err := srs.OpenMulti(p, zs)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want := fft.PolyEvalMany(p, zs); fft.IsPolyEqual(ys, want) == false {
+				t.Errorf("OpenMulti: ys did not match p(zs)")
+			}
+			if ok, err := srs.VerifyMulti(&c, &proof, zs, ys); err != nil || ok == false {
+				t.Errorf("VerifyMulti: rejected a valid opening, %v", err)
+			}
+
+			ys[tt.k-1] = *ff.RandomFr()
+			if ok, err := srs.VerifyMulti(&c, &proof, zs, ys); err != nil || ok {
+				t.Errorf("VerifyMulti: accepted a wrong value, %v", err)
+			}
+		})
+	}
+}
+
+func TestOpenMultiErrors(t *testing.T) {
+	srs := NewSRSInsecure(ff.RandomFr(), 8, 3)
+	p := randomPoly(8)
+	c, _ := srs.Commit(p)
+	var proof gmcl.G1
+	dup := []ff.Fr{*ff.RandomFr()}
+	dup = append(dup, dup[0])
+
+	var tests = []struct {
+		name string
+		run  func() error
+		want error
+	}{
+		{"empty-poly", func() error { _, _, err := srs.OpenMulti(nil, randomPoly(2)); return err }, ErrEmptyInput},
+		{"empty-points", func() error { _, _, err := srs.OpenMulti(p, nil); return err }, ErrEmptyInput},
+		{"srs-g2", func() error { _, _, err := srs.OpenMulti(p, randomPoly(3)); return err }, ErrSRSTooSmall},
+		{"duplicates", func() error { _, _, err := srs.OpenMulti(p, dup); return err }, ErrDuplicatePoints},
+		{"srs-g1", func() error { _, _, err := srs.Open(randomPoly(10), ff.RandomFr()); return err }, ErrSRSTooSmall},
+		{"verify-duplicates", func() error { _, err := srs.VerifyMulti(&c, &proof, dup, dup); return err }, ErrDuplicatePoints},
+		{"verify-srs-g2", func() error { _, err := srs.VerifyMulti(&c, &proof, randomPoly(3), randomPoly(3)); return err }, ErrSRSTooSmall},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.run(); errors.Is(err, tt.want) == false {
+				t.Errorf("expected %v, got %v", tt.want, err)
+			}
+		})
+	}
+}
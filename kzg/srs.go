@@ -0,0 +1,39 @@
+//go:build !bignum_pure && !bignum_hol256 && !bignum_kilic && !bignum_hbls
+// +build !bignum_pure,!bignum_hol256,!bignum_kilic,!bignum_hbls
+
+package kzg
+
+import (
+	"github.com/accumulators-agg/go-poly/ff"
+	gmcl "github.com/alinush/go-mcl"
+)
+
+// Structured reference string: the powers of a secret tau in both groups.
+type SRS struct {
+	// [tau^i]G1 for i < len(G1), commits to polynomials of up to len(G1) coefficients
+	G1 []gmcl.G1
+	// [tau^i]G2 for i < len(G2), at least [1]G2 and [tau]G2.
+	// Opening k points at once needs k + 1 of them.
+	G2 []gmcl.G2
+}
+
+// Builds an SRS with n1 powers in G1 and n2 (at least 2) powers in G2 from a known tau.
+// For tests only: whoever knows tau can open a commitment to any value.
+func NewSRSInsecure(tau *ff.Fr, n1 int, n2 int) *SRS {
+	if n2 < 2 {
+		n2 = 2
+	}
+	srs := &SRS{G1: make([]gmcl.G1, n1), G2: make([]gmcl.G2, n2)}
+	var pow ff.Fr
+	pow.SetInt64(1)
+	for i := 0; i < n1 || i < n2; i++ {
+		if i < n1 {
+			gmcl.G1Mul(&srs.G1[i], &ff.GenG1, &pow)
+		}
+		if i < n2 {
+			gmcl.G2Mul(&srs.G2[i], &ff.GenG2, &pow)
+		}
+		ff.FrMul(&pow, &pow, tau)
+	}
+	return srs
+}
@@ -3,10 +3,10 @@ package main
 import (
 	"fmt"
 
-	gmcl "github.com/alinush/go-mcl"
+	// Initializes the field backend selected by the bignum_* build tags.
+	_ "github.com/accumulators-agg/go-poly/ff"
 )
 
 func main() {
 	fmt.Println("Hello, World!")
-	gmcl.InitFromString("bls12-381")
 }